@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Account is a ledger account.
+type Account struct {
+	ID          tb_types.Uint128
+	Ledger      uint32
+	Code        uint16
+	Flags       tb_types.AccountFlags
+	UserData128 tb_types.Uint128
+	UserData64  uint64
+	UserData32  uint32
+
+	// Totals of the account, set on accounts returned by the service
+	DebitsPending  *big.Int
+	DebitsPosted   *big.Int
+	CreditsPending *big.Int
+	CreditsPosted  *big.Int
+}
+
+// Balance returns the posted credits minus the posted debits.
+func (a *Account) Balance() *big.Int {
+	balance := new(big.Int)
+	if a.CreditsPosted != nil {
+		balance.Set(a.CreditsPosted)
+	}
+	if a.DebitsPosted != nil {
+		balance.Sub(balance, a.DebitsPosted)
+	}
+	return balance
+}
+
+// CreateAccount creates an account and returns it as stored. A zero ID is
+// replaced with a generated one.
+func (c *Client) CreateAccount(ctx context.Context, account Account) (*Account, error) {
+	req := &pb.CreateAccountRequest{
+		Id:           tbutil.Uint128ToString(stableID(account.ID)),
+		Ledger:       account.Ledger,
+		Code:         uint32(account.Code),
+		Flags:        uint32(account.Flags.ToUint16()),
+		UserData_128: formatOptional(account.UserData128),
+		UserData_64:  account.UserData64,
+		UserData_32:  account.UserData32,
+	}
+	resp, err := invoke(ctx, c, c.rpc.CreateAccount, req)
+	if err != nil {
+		return nil, err
+	}
+	return accountFromResponse(resp)
+}
+
+// GetAccount returns an account with its current totals.
+func (c *Client) GetAccount(ctx context.Context, id tb_types.Uint128) (*Account, error) {
+	resp, err := invoke(ctx, c, c.rpc.GetAccount, &pb.GetAccountRequest{Id: tbutil.Uint128ToString(id)})
+	if err != nil {
+		return nil, err
+	}
+	return accountFromResponse(resp)
+}
+
+func accountFromResponse(resp *pb.AccountResponse) (*Account, error) {
+	var p parser
+	account := &Account{
+		ID:             p.id("id", resp.Id),
+		Ledger:         resp.Ledger,
+		Code:           uint16(resp.Code),
+		Flags:          tb_types.Account{Flags: uint16(resp.Flags)}.AccountFlags(),
+		UserData128:    p.id("user_data_128", resp.UserData_128),
+		UserData64:     resp.UserData_64,
+		UserData32:     resp.UserData_32,
+		DebitsPending:  p.amount("debits_pending", resp.DebitsPending),
+		DebitsPosted:   p.amount("debits_posted", resp.DebitsPosted),
+		CreditsPending: p.amount("credits_pending", resp.CreditsPending),
+		CreditsPosted:  p.amount("credits_posted", resp.CreditsPosted),
+	}
+	return account, p.err
+}
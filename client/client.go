@@ -0,0 +1,139 @@
+// Package client is a Go client for the financial service. It wraps the
+// generated gRPC client with typed accounts and transfers, big.Int amounts,
+// retries that are safe to repeat, default deadlines and errors carrying
+// the TigerBeetle result reason.
+//
+// Every create call fixes the IDs of the objects it creates before the first
+// attempt, generating them if the caller left them zero. A retried call
+// therefore finds the object created by an attempt whose response was lost,
+// which the service reports as a success, and never applies it twice.
+package client
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultTimeout bounds calls whose context has no deadline, retries
+	// included
+	DefaultTimeout = 10 * time.Second
+	// DefaultAttempts is how many times a call is tried
+	DefaultAttempts = 3
+	// DefaultBackoff is the wait before the first retry; it doubles on each
+	// further retry up to maxBackoff
+	DefaultBackoff = 100 * time.Millisecond
+
+	maxBackoff = 2 * time.Second
+)
+
+// Client calls the financial service. It is safe for concurrent use.
+type Client struct {
+	rpc      pb.FinancialServiceClient
+	conn     *grpc.ClientConn
+	timeout  time.Duration
+	attempts int
+	backoff  time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTimeout sets the deadline of calls whose context has none. Zero
+// leaves such calls unbounded.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetries sets how many times a call is tried when the service is
+// unavailable or overloaded, and the wait before the first retry. One
+// attempt disables retries.
+func WithRetries(attempts int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.attempts = max(attempts, 1)
+		c.backoff = backoff
+	}
+}
+
+// New returns a client using conn, which the caller keeps ownership of.
+func New(conn grpc.ClientConnInterface, opts ...Option) *Client {
+	c := &Client{
+		rpc:      pb.NewFinancialServiceClient(conn),
+		timeout:  DefaultTimeout,
+		attempts: DefaultAttempts,
+		backoff:  DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Dial connects to the service at target, e.g. "ledger.internal:50051",
+// with the given transport credentials. Close releases the connection.
+func Dial(target string, creds credentials.TransportCredentials, opts ...Option) (*Client, error) {
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	c := New(conn, opts...)
+	c.conn = conn
+	return c, nil
+}
+
+// Close closes the connection opened by Dial. It does nothing on clients
+// built with New.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// invoke calls rpc, retrying it while the service is unavailable, and
+// converts a final failure into an *Error
+func invoke[Req, Resp any](ctx context.Context, c *Client, rpc func(context.Context, Req, ...grpc.CallOption) (Resp, error), req Req) (Resp, error) {
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := rpc(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt >= c.attempts || !retryable(err) {
+			return resp, newError(err)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, newError(err)
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+// retryable reports failures that may succeed if tried again unchanged
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/client"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/service"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves the financial service on an in-memory ledger, with
+// interceptor in front of it
+func startServer(t *testing.T, interceptor grpc.UnaryServerInterceptor, opts ...client.Option) *client.Client {
+	t.Helper()
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+	pb.RegisterFinancialServiceServer(server, service.NewFinancialService(repo))
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return client.New(conn, append([]client.Option{client.WithRetries(3, time.Millisecond)}, opts...)...)
+}
+
+func passthrough(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(ctx, req)
+}
+
+func createAccounts(t *testing.T, c *client.Client, flags tb_types.AccountFlags) (debit, credit *client.Account) {
+	t.Helper()
+	ctx := context.Background()
+	debit, err := c.CreateAccount(ctx, client.Account{Ledger: 1, Code: 1, Flags: flags})
+	require.NoError(t, err)
+	credit, err = c.CreateAccount(ctx, client.Account{Ledger: 1, Code: 1})
+	require.NoError(t, err)
+	return debit, credit
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c := startServer(t, passthrough)
+	debit, credit := createAccounts(t, c, tb_types.AccountFlags{History: true})
+	assert.NotEqual(t, tb_types.Uint128{}, debit.ID)
+	assert.True(t, debit.Flags.History)
+
+	// Beyond 64 bits
+	large, ok := new(big.Int).SetString("100000000000000000000000", 10)
+	require.True(t, ok)
+	transfer, err := c.Transfer(ctx, client.Transfer{
+		DebitAccountID: debit.ID, CreditAccountID: credit.ID, Amount: large, Ledger: 1, Code: 7,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, large.Cmp(transfer.Amount))
+	assert.Equal(t, uint16(7), transfer.Code)
+
+	account, err := c.GetAccount(ctx, credit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, large.Cmp(account.CreditsPosted))
+	assert.Equal(t, 0, large.Cmp(account.Balance()))
+
+	t.Run("reserve and capture", func(t *testing.T) {
+		pending, err := c.Reserve(ctx, client.Transfer{
+			DebitAccountID: debit.ID, CreditAccountID: credit.ID, Amount: big.NewInt(50), Ledger: 1, Code: 1,
+		})
+		require.NoError(t, err)
+		assert.True(t, pending.Flags.Pending)
+
+		posted, err := c.Capture(ctx, tb_types.Uint128{}, pending.ID, big.NewInt(20))
+		require.NoError(t, err)
+		assert.Equal(t, pending.ID, posted.PendingID)
+		assert.Equal(t, int64(20), posted.Amount.Int64())
+
+		got, err := c.GetTransfer(ctx, posted.ID)
+		require.NoError(t, err)
+		assert.True(t, got.Flags.PostPendingTransfer)
+	})
+
+	t.Run("reserve and void", func(t *testing.T) {
+		pending, err := c.Reserve(ctx, client.Transfer{
+			DebitAccountID: debit.ID, CreditAccountID: credit.ID, Amount: big.NewInt(5), Ledger: 1, Code: 1,
+		})
+		require.NoError(t, err)
+
+		id := tb_types.ID()
+		voided, err := c.Void(ctx, id, pending.ID)
+		require.NoError(t, err)
+		assert.Equal(t, id, voided.ID)
+		assert.True(t, voided.Flags.VoidPendingTransfer)
+
+		// A retry with the same ID finds the voiding transfer
+		again, err := c.Void(ctx, id, pending.ID)
+		require.NoError(t, err)
+		assert.Equal(t, voided.Timestamp, again.Timestamp)
+
+		_, err = c.Void(ctx, tb_types.Uint128{}, pending.ID)
+		assert.ErrorIs(t, err, client.ReasonPendingTransferAlreadyVoided)
+	})
+
+	t.Run("amount beyond 128 bits", func(t *testing.T) {
+		_, err := c.Transfer(ctx, client.Transfer{
+			DebitAccountID: debit.ID, CreditAccountID: credit.ID, Amount: new(big.Int).Lsh(big.NewInt(1), 128), Ledger: 1, Code: 1,
+		})
+		assert.ErrorContains(t, err, "exceeds 128")
+	})
+}
+
+func TestClientErrors(t *testing.T) {
+	ctx := context.Background()
+	c := startServer(t, passthrough)
+	debit, credit := createAccounts(t, c, tb_types.AccountFlags{DebitsMustNotExceedCredits: true})
+
+	_, err := c.Transfer(ctx, client.Transfer{
+		DebitAccountID: debit.ID, CreditAccountID: credit.ID, Amount: big.NewInt(1), Ledger: 1, Code: 1,
+	})
+	assert.ErrorIs(t, err, client.ReasonExceedsCredits)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	var clientErr *client.Error
+	require.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, client.ReasonExceedsCredits, clientErr.Reason)
+
+	_, err = c.GetAccount(ctx, tb_types.ToUint128(424242))
+	assert.ErrorIs(t, err, client.ReasonAccountNotFound)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClientRetries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("a lost response is retried without applying the transfer twice", func(t *testing.T) {
+		var lost atomic.Bool
+		c := startServer(t, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			resp, err := handler(ctx, req)
+			if info.FullMethod == pb.FinancialService_CreateTransfer_FullMethodName && lost.CompareAndSwap(false, true) {
+				return nil, status.Error(codes.Unavailable, "connection reset")
+			}
+			return resp, err
+		})
+		debit, credit := createAccounts(t, c, tb_types.AccountFlags{})
+
+		transfer, err := c.Transfer(ctx, client.Transfer{
+			DebitAccountID: debit.ID, CreditAccountID: credit.ID, Amount: big.NewInt(10), Ledger: 1, Code: 1,
+		})
+		require.NoError(t, err)
+		assert.True(t, lost.Load())
+		assert.NotEqual(t, tb_types.Uint128{}, transfer.ID)
+
+		account, err := c.GetAccount(ctx, debit.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), account.DebitsPosted.Int64())
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		var calls atomic.Int32
+		c := startServer(t, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls.Add(1)
+			return nil, status.Error(codes.Unavailable, "down")
+		})
+
+		_, err := c.GetAccount(ctx, tb_types.ToUint128(1))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("rejections are not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		c := startServer(t, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls.Add(1)
+			return handler(ctx, req)
+		})
+
+		_, err := c.GetAccount(ctx, tb_types.ToUint128(1))
+		assert.True(t, errors.Is(err, client.ReasonAccountNotFound))
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("calls without a deadline get the default", func(t *testing.T) {
+		c := startServer(t, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}, client.WithTimeout(20*time.Millisecond))
+
+		start := time.Now()
+		_, err := c.GetAccount(ctx, tb_types.ToUint128(1))
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestIDsAndAmounts(t *testing.T) {
+	id, err := client.ParseID("0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59")
+	require.NoError(t, err)
+	assert.Equal(t, "0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59", client.FormatUUID(id))
+
+	decimal, err := client.ParseID(client.FormatID(id))
+	require.NoError(t, err)
+	assert.Equal(t, id, decimal)
+
+	amount, err := client.ParseAmount("340282366920938463463374607431768211455")
+	require.NoError(t, err)
+	formatted, err := client.FormatAmount(amount)
+	require.NoError(t, err)
+	assert.Equal(t, "340282366920938463463374607431768211455", formatted)
+
+	_, err = client.ParseAmount("-1")
+	assert.Error(t, err)
+	_, err = client.FormatAmount(new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.ErrorContains(t, err, "exceeds 128")
+}
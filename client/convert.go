@@ -0,0 +1,70 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// stableID returns id, or a new one if it is zero, so that retries of a
+// create reuse the same ID
+func stableID(id tb_types.Uint128) tb_types.Uint128 {
+	if id == (tb_types.Uint128{}) {
+		return tb_types.ID()
+	}
+	return id
+}
+
+// formatOptional formats a Uint128 the service treats as unset when empty
+func formatOptional(u tb_types.Uint128) string {
+	if u == (tb_types.Uint128{}) {
+		return ""
+	}
+	return tbutil.Uint128ToString(u)
+}
+
+// formatAmount formats an amount; nil is left for the service to default
+func formatAmount(amount *big.Int) (string, error) {
+	if amount == nil {
+		return "", nil
+	}
+	if _, err := tbutil.BigIntToUint128(amount); err != nil {
+		return "", fmt.Errorf("amount %s: %w", amount, err)
+	}
+	return amount.String(), nil
+}
+
+// parser reads the numbers of a response, keeping the first error
+type parser struct {
+	err error
+}
+
+func (p *parser) id(field, s string) tb_types.Uint128 {
+	if s == "" {
+		return tb_types.Uint128{}
+	}
+	u, err := tbutil.ParseID(s)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("response field %s: %w", field, err)
+	}
+	return u
+}
+
+func (p *parser) amount(field, s string) *big.Int {
+	return tbutil.Uint128ToBigInt(p.id(field, s))
+}
+
+func (p *parser) code(field, s string) uint16 {
+	if s == "" {
+		return 0
+	}
+	code, err := strconv.ParseUint(s, 10, 16)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("response field %s: %w", field, err)
+	}
+	return uint16(code)
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reason says why the service rejected a call. Ledger rejections use the
+// upper-cased TigerBeetle result name, e.g. EXCEEDS_CREDITS; the constants
+// below list the ones callers most often branch on.
+type Reason string
+
+func (r Reason) Error() string {
+	return string(r)
+}
+
+// Reasons returned by the service.
+const (
+	ReasonValidationFailed = Reason("VALIDATION_FAILED")
+	ReasonAccountNotFound  = Reason("ACCOUNT_NOT_FOUND")
+	ReasonTransferNotFound = Reason("TRANSFER_NOT_FOUND")
+
+	ReasonExceedsCredits               = Reason("EXCEEDS_CREDITS")
+	ReasonExceedsDebits                = Reason("EXCEEDS_DEBITS")
+	ReasonDebitAccountNotFound         = Reason("DEBIT_ACCOUNT_NOT_FOUND")
+	ReasonCreditAccountNotFound        = Reason("CREDIT_ACCOUNT_NOT_FOUND")
+	ReasonPendingTransferNotFound      = Reason("PENDING_TRANSFER_NOT_FOUND")
+	ReasonPendingTransferExpired       = Reason("PENDING_TRANSFER_EXPIRED")
+	ReasonPendingTransferAlreadyPosted = Reason("PENDING_TRANSFER_ALREADY_POSTED")
+	ReasonPendingTransferAlreadyVoided = Reason("PENDING_TRANSFER_ALREADY_VOIDED")
+	ReasonLinkedEventFailed            = Reason("LINKED_EVENT_FAILED")
+)
+
+// Error is a failed call. It unwraps to its Reason, so that
+//
+//	errors.Is(err, client.ReasonExceedsCredits)
+//
+// tells whether a transfer failed for lack of funds, and status.Code(err)
+// still returns the gRPC code.
+type Error struct {
+	Code    codes.Code
+	Message string
+	// Reason is empty if the service gave none, e.g. when it could not be
+	// reached
+	Reason Reason
+
+	status *status.Status
+}
+
+func newError(err error) *Error {
+	st := status.Convert(err)
+	e := &Error{Code: st.Code(), Message: st.Message(), status: st}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			e.Reason = Reason(info.Reason)
+			break
+		}
+	}
+	return e
+}
+
+func (e *Error) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s (%s): %s", e.Code, e.Reason, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	if e.Reason == "" {
+		return nil
+	}
+	return e.Reason
+}
+
+// GRPCStatus returns the status the service replied with.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.status
+}
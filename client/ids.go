@@ -0,0 +1,45 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// ParseID parses an ID given either in decimal form or as a UUID. Both forms
+// of the same ID are interchangeable.
+func ParseID(s string) (tb_types.Uint128, error) {
+	return tbutil.ParseID(s)
+}
+
+// FormatID formats an ID in decimal form.
+func FormatID(id tb_types.Uint128) string {
+	return tbutil.Uint128ToString(id)
+}
+
+// FormatUUID formats an ID in the canonical UUID form accepted by ParseID.
+func FormatUUID(id tb_types.Uint128) string {
+	return tbutil.FormatUUID(id)
+}
+
+// ParseAmount parses a decimal amount. It fails if the amount is negative or
+// does not fit in 128 bits.
+func ParseAmount(s string) (*big.Int, error) {
+	u, err := tbutil.ParseUint128FromString(s)
+	if err != nil {
+		return nil, fmt.Errorf("amount %q: %w", s, err)
+	}
+	return tbutil.Uint128ToBigInt(u), nil
+}
+
+// FormatAmount formats an amount in decimal form. It fails if the amount is
+// negative or does not fit in 128 bits.
+func FormatAmount(amount *big.Int) (string, error) {
+	if amount == nil {
+		return "0", nil
+	}
+	return formatAmount(amount)
+}
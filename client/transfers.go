@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Transfer moves Amount from the debit account to the credit account.
+type Transfer struct {
+	ID              tb_types.Uint128
+	DebitAccountID  tb_types.Uint128
+	CreditAccountID tb_types.Uint128
+	Amount          *big.Int
+	// PendingID is the pending transfer a post or void resolves
+	PendingID tb_types.Uint128
+	Ledger    uint32
+	Code      uint16
+	Flags     tb_types.TransferFlags
+	// Timeout is how many seconds a pending transfer holds its funds; zero
+	// holds them until posted or voided
+	Timeout     uint32
+	UserData128 tb_types.Uint128
+	UserData64  uint64
+	UserData32  uint32
+
+	// Timestamp is set by the ledger
+	Timestamp uint64
+}
+
+// Transfer creates a transfer and returns it as stored. A zero ID is
+// replaced with a generated one.
+func (c *Client) Transfer(ctx context.Context, transfer Transfer) (*Transfer, error) {
+	amount, err := formatAmount(transfer.Amount)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.CreateTransferRequest{
+		Id:              tbutil.Uint128ToString(stableID(transfer.ID)),
+		DebitAccountId:  formatOptional(transfer.DebitAccountID),
+		CreditAccountId: formatOptional(transfer.CreditAccountID),
+		Amount:          amount,
+		PendingId:       formatOptional(transfer.PendingID),
+		Ledger:          transfer.Ledger,
+		Code:            strconv.FormatUint(uint64(transfer.Code), 10),
+		Flags:           uint32(transfer.Flags.ToUint16()),
+		Timeout:         transfer.Timeout,
+		UserData_128:    formatOptional(transfer.UserData128),
+		UserData_64:     transfer.UserData64,
+		UserData_32:     transfer.UserData32,
+	}
+	resp, err := invoke(ctx, c, c.rpc.CreateTransfer, req)
+	if err != nil {
+		return nil, err
+	}
+	return transferFromResponse(resp)
+}
+
+// GetTransfer returns a transfer.
+func (c *Client) GetTransfer(ctx context.Context, id tb_types.Uint128) (*Transfer, error) {
+	resp, err := invoke(ctx, c, c.rpc.GetTransfer, &pb.GetTransferRequest{Id: tbutil.Uint128ToString(id)})
+	if err != nil {
+		return nil, err
+	}
+	return transferFromResponse(resp)
+}
+
+// Reserve holds Amount of the debit account in a pending transfer, to be
+// resolved with Capture or Void. Flags are ignored.
+func (c *Client) Reserve(ctx context.Context, transfer Transfer) (*Transfer, error) {
+	amount, err := formatAmount(transfer.Amount)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.ReserveFundsRequest{
+		Id:              tbutil.Uint128ToString(stableID(transfer.ID)),
+		DebitAccountId:  formatOptional(transfer.DebitAccountID),
+		CreditAccountId: formatOptional(transfer.CreditAccountID),
+		Amount:          amount,
+		Ledger:          transfer.Ledger,
+		Code:            strconv.FormatUint(uint64(transfer.Code), 10),
+		Timeout:         transfer.Timeout,
+		UserData_128:    formatOptional(transfer.UserData128),
+		UserData_64:     transfer.UserData64,
+		UserData_32:     transfer.UserData32,
+	}
+	resp, err := invoke(ctx, c, c.rpc.ReserveFunds, req)
+	if err != nil {
+		return nil, err
+	}
+	return transferFromResponse(resp)
+}
+
+// Capture posts a pending transfer and returns the posting transfer. A zero
+// id is replaced with a generated one; a nil amount posts the full pending
+// amount.
+func (c *Client) Capture(ctx context.Context, id, pendingID tb_types.Uint128, amount *big.Int) (*Transfer, error) {
+	formatted, err := formatAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.CapturePendingRequest{
+		Id:        tbutil.Uint128ToString(stableID(id)),
+		PendingId: tbutil.Uint128ToString(pendingID),
+		Amount:    formatted,
+	}
+	resp, err := invoke(ctx, c, c.rpc.CapturePending, req)
+	if err != nil {
+		return nil, err
+	}
+	return transferFromResponse(resp)
+}
+
+// Void releases the funds of a pending transfer and returns the voiding
+// transfer. A zero id is replaced with a generated one.
+func (c *Client) Void(ctx context.Context, id, pendingID tb_types.Uint128) (*Transfer, error) {
+	req := &pb.VoidPendingRequest{
+		Id:        tbutil.Uint128ToString(stableID(id)),
+		PendingId: tbutil.Uint128ToString(pendingID),
+	}
+	resp, err := invoke(ctx, c, c.rpc.VoidPending, req)
+	if err != nil {
+		return nil, err
+	}
+	return transferFromResponse(resp)
+}
+
+func transferFromResponse(resp *pb.TransferResponse) (*Transfer, error) {
+	var p parser
+	transfer := &Transfer{
+		ID:              p.id("id", resp.Id),
+		DebitAccountID:  p.id("debit_account_id", resp.DebitAccountId),
+		CreditAccountID: p.id("credit_account_id", resp.CreditAccountId),
+		Amount:          p.amount("amount", resp.Amount),
+		PendingID:       p.id("pending_id", resp.PendingId),
+		Ledger:          resp.Ledger,
+		Code:            p.code("code", resp.Code),
+		Flags:           tb_types.Transfer{Flags: uint16(resp.Flags)}.TransferFlags(),
+		Timeout:         resp.Timeout,
+		UserData128:     p.id("user_data_128", resp.UserData_128),
+		UserData64:      resp.UserData_64,
+		UserData32:      resp.UserData_32,
+		Timestamp:       resp.Timestamp,
+	}
+	return transfer, p.err
+}
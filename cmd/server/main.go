@@ -1,46 +1,119 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/auth"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/config"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/gateway"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/health"
 	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/metrics"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/middleware"
 	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
 	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/service"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tlsconfig"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
 	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
 
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	logger.Init(false)
-	// Parâmetros da linha de comando
-	port := flag.Int("port", 50051, "Porta do servidor gRPC")
-	clusterID := 0
-	flag.Parse()
+	// Configuração: padrões < arquivo YAML < variáveis de ambiente < flags
+	cfg, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuração inválida:\n%v\n", err)
+		os.Exit(2)
+	}
+
+	if cfg.PrintConfig {
+		if err := cfg.Write(os.Stdout); err != nil {
+			log.Fatalf("Falha ao imprimir configuração: %v", err)
+		}
+		return
+	}
 
-	// Divide os endereços do TigerBeetle
-	addresses := "3000"
+	if err := logger.Setup(cfg.Log.Level, cfg.Log.Format); err != nil {
+		log.Fatalf("Falha ao configurar o logger: %v", err)
+	}
 
-	// Inicializa o repositório TigerBeetle
-	repo, err := repository.NewTigerBeetleRepository([]string{addresses}, uint64(clusterID))
+	// Tracing OpenTelemetry; o exportador stdout é útil em desenvolvimento
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, os.Stdout)
 	if err != nil {
-		log.Fatalf("Falha ao inicializar repositório TigerBeetle: %v", err)
+		log.Fatalf("Falha ao configurar tracing: %v", err)
+	}
+
+	// Métricas Prometheus, opcionais
+	var m *metrics.Metrics
+	var repoOpts []repository.Option
+	if cfg.Metrics.Listen != "" {
+		m = metrics.New()
+		repoOpts = append(repoOpts, repository.WithObserver(m))
 	}
-	defer repo.Close()
+	// Agrupa chamadas concorrentes de CreateTransfer, se habilitado
+	if cfg.TigerBeetle.BatchWindow > 0 {
+		repoOpts = append(repoOpts, repository.WithTransferBatching(cfg.TigerBeetle.BatchWindow, cfg.TigerBeetle.BatchSize))
+	}
+
+	var repo *repository.TigerBeetleRepository
+	if cfg.Memory {
+		// Ledger em memória com a mesma semântica do TigerBeetle
+		repo = repository.NewInMemoryRepository(repoOpts...)
+		log.Printf("Usando ledger em memória")
+	} else {
+		// Inicializa o repositório TigerBeetle
+		repo, err = repository.NewTigerBeetleRepository(cfg.TigerBeetle.Addresses, cfg.TigerBeetle.ClusterID, repoOpts...)
+		if err != nil {
+			log.Fatalf("Falha ao inicializar repositório TigerBeetle: %v", err)
+		}
 
-	println("Conectado ao TigerBettle")
+		log.Printf("Conectado ao TigerBeetle (cluster %d, réplicas %v)", cfg.TigerBeetle.ClusterID, cfg.TigerBeetle.Addresses)
+	}
 
 	// Inicializa o servidor gRPC
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
+	lis, err := net.Listen("tcp", cfg.Listen)
 	if err != nil {
-		log.Fatalf("Falha ao escutar na porta %d: %v", *port, err)
+		log.Fatalf("Falha ao escutar em %s: %v", cfg.Listen, err)
+	}
+
+	// Autenticação e autorização, se houver uma política configurada
+	var authz *auth.Interceptor
+	if cfg.Auth.Enabled() {
+		authz, err = newAuthInterceptor(cfg, repo)
+		if err != nil {
+			log.Fatalf("Falha ao configurar autenticação: %v", err)
+		}
+	}
+
+	// Certificados TLS, recarregados quando os arquivos mudam
+	var certs *tlsconfig.Reloader
+	if cfg.TLS.Enabled() {
+		certs, err = tlsconfig.NewReloader(cfg.TLS)
+		if err != nil {
+			log.Fatalf("Falha ao configurar TLS: %v", err)
+		}
 	}
 
-	grpcServer := grpc.NewServer()
+	tracker := middleware.NewTracker()
+	grpcServer := grpc.NewServer(serverOptions(cfg, tracker, m, authz, certs)...)
 
 	// Registra o serviço financeiro
 	financialService := service.NewFinancialService(repo)
@@ -49,10 +122,105 @@ func main() {
 	// Habilita reflection para ferramentas como grpcurl
 	reflection.Register(grpcServer)
 
-	log.Printf("Servidor gRPC iniciado na porta %d", *port)
-	if err := grpcServer.Serve(lis); err != nil {
+	// Encerra de forma ordenada em SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Health check padrão (grpc.health.v1), acompanhando a disponibilidade
+	// do TigerBeetle
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	checker := health.NewChecker(repo, healthServer, cfg.Health.Interval, cfg.Health.Timeout,
+		pb.FinancialService_ServiceDesc.ServiceName)
+	go checker.Run(ctx)
+
+	if certs != nil {
+		go func() {
+			if err := certs.Watch(ctx); err != nil {
+				log.Printf("Recarga automática de certificados desativada: %v", err)
+			}
+		}()
+	}
+
+	// Gateway HTTP/JSON, com os mesmos interceptores das chamadas gRPC
+	var gatewayServer *http.Server
+	if cfg.Gateway.Listen != "" {
+		gw := gateway.New(financialService, unaryInterceptors(cfg, tracker, m, authz)...)
+		gatewayServer, err = serveGateway(cfg, gw, certs)
+		if err != nil {
+			repo.Close()
+			log.Fatalf("Falha ao iniciar o gateway: %v", err)
+		}
+		log.Printf("Gateway HTTP/JSON iniciado em %s", cfg.Gateway.Listen)
+	}
+
+	var metricsServer *http.Server
+	if m != nil {
+		metricsServer, err = serveMetrics(cfg.Metrics.Listen, m)
+		if err != nil {
+			repo.Close()
+			log.Fatalf("Falha ao iniciar métricas: %v", err)
+		}
+		log.Printf("Métricas disponíveis em http://%s/metrics", cfg.Metrics.Listen)
+	}
+
+	var logLevelServer *http.Server
+	if cfg.Log.AdminListen != "" {
+		logLevelServer, err = serveLogLevel(cfg.Log.AdminListen)
+		if err != nil {
+			repo.Close()
+			log.Fatalf("Falha ao iniciar o endpoint de nível de log: %v", err)
+		}
+		log.Printf("Nível de log disponível em http://%s/loglevel", cfg.Log.AdminListen)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- grpcServer.Serve(lis)
+	}()
+	log.Printf("Servidor gRPC iniciado em %s", cfg.Listen)
+
+	select {
+	case err := <-serveErr:
+		repo.Close()
 		log.Fatalf("Falha ao servir: %v", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	log.Printf("Sinal recebido, encerrando (prazo de %s)", cfg.ShutdownTimeout)
+	// Sinaliza NOT_SERVING para que o orquestrador pare de enviar tráfego
+	healthServer.Shutdown()
+	// O gateway e o gRPC drenam ao mesmo tempo, dentro do mesmo prazo
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	var draining sync.WaitGroup
+	if gatewayServer != nil {
+		draining.Add(1)
+		go func() {
+			defer draining.Done()
+			if err := gatewayServer.Shutdown(shutdownCtx); err != nil {
+				gatewayServer.Close()
+			}
+		}()
+	}
+	shutdown(shutdownCtx, grpcServer, tracker)
+	draining.Wait()
+	cancelShutdown()
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+	if logLevelServer != nil {
+		logLevelServer.Close()
 	}
 
-	log.Printf("Teste")
+	// Fecha o cliente só depois que nenhuma chamada o utiliza mais
+	repo.Close()
+
+	// Envia os spans pendentes antes de sair
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Printf("Falha ao enviar spans pendentes: %v", err)
+	}
+	log.Printf("Servidor encerrado")
 }
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/auth"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/config"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/gateway"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/metrics"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/middleware"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tlsconfig"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// serverOptions monta as opções do servidor gRPC a partir da configuração.
+// O tracker registra as chamadas em andamento para o encerramento; m, se não
+// for nil, recebe as métricas de cada chamada, authz, se não for nil,
+// autentica e autoriza as chamadas, e certs fornece os certificados quando
+// TLS está habilitado.
+func serverOptions(cfg *config.Config, tracker *middleware.Tracker, m *metrics.Metrics, authz *auth.Interceptor, certs *tlsconfig.Reloader) []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		// Um span por chamada, continuando o trace recebido nos metadados
+		grpc.StatsHandler(tracing.ServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryInterceptors(cfg, tracker, m, authz)...),
+		// A recuperação de panics fica por fora de todos os interceptores
+		grpc.ChainStreamInterceptor(middleware.StreamRecovery(), tracker.StreamInterceptor(), middleware.StreamLogging()),
+	}
+
+	if m != nil {
+		opts = append(opts, grpc.ChainStreamInterceptor(m.StreamInterceptor()))
+	}
+
+	if authz != nil {
+		opts = append(opts, grpc.ChainStreamInterceptor(authz.StreamInterceptor()))
+	}
+
+	if certs != nil {
+		// Com autenticação, o certificado do cliente é uma das credenciais
+		// aceitas e a exigência fica com o interceptor
+		opts = append(opts, grpc.Creds(credentials.NewTLS(certs.ServerConfig(cfg.Auth.Enabled()))))
+	}
+
+	return opts
+}
+
+// unaryInterceptors lista os interceptores das chamadas unárias, na ordem
+// em que são aplicados. O gateway HTTP usa a mesma cadeia. A recuperação de
+// panics vem primeiro, para cobrir também os demais interceptores.
+func unaryInterceptors(cfg *config.Config, tracker *middleware.Tracker, m *metrics.Metrics, authz *auth.Interceptor) []grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{middleware.UnaryRecovery(), tracker.UnaryInterceptor(), middleware.UnaryLogging()}
+	if m != nil {
+		interceptors = append(interceptors, m.UnaryInterceptor())
+	}
+	if authz != nil {
+		interceptors = append(interceptors, authz.UnaryInterceptor())
+	}
+	if cfg.RequestTimeout > 0 {
+		interceptors = append(interceptors, middleware.Timeout(cfg.RequestTimeout))
+	}
+	return interceptors
+}
+
+// serveMetrics expõe as métricas em /metrics no endereço informado
+func serveMetrics(addr string, m *metrics.Metrics) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	return serveHTTP(addr, mux, "métricas")
+}
+
+// serveLogLevel expõe /loglevel, que permite consultar (GET) e alterar (PUT)
+// o nível de log sem reiniciar o servidor. Não há autenticação: o endereço
+// fica separado do de métricas para que só a rede de administração o alcance.
+func serveLogLevel(addr string) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.Handle("/loglevel", logger.LevelHandler())
+	return serveHTTP(addr, mux, "nível de log")
+}
+
+// serveHTTP serve handler no endereço informado; name identifica o servidor
+// nos logs
+func serveHTTP(addr string, handler http.Handler, name string) (*http.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao escutar em %s: %w", addr, err)
+	}
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Falha ao servir %s: %v", name, err)
+		}
+	}()
+	return server, nil
+}
+
+// serveGateway serve o gateway HTTP/JSON no endereço informado, com TLS
+// quando certs não é nil
+func serveGateway(cfg *config.Config, gw *gateway.Gateway, certs *tlsconfig.Reloader) (*http.Server, error) {
+	lis, err := net.Listen("tcp", cfg.Gateway.Listen)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao escutar em %s: %w", cfg.Gateway.Listen, err)
+	}
+
+	server := &http.Server{Handler: gw.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	if certs != nil {
+		server.TLSConfig = certs.ServerConfig(cfg.Auth.Enabled())
+	}
+
+	go func() {
+		var err error
+		if certs != nil {
+			err = server.ServeTLS(lis, "", "")
+		} else {
+			err = server.Serve(lis)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Falha ao servir o gateway: %v", err)
+		}
+	}()
+	return server, nil
+}
+
+// shutdown para de aceitar chamadas e aguarda as que estão em andamento até
+// o prazo de ctx. Se o prazo acabar, informa quais chamadas ainda estavam em
+// andamento e as interrompe.
+func shutdown(ctx context.Context, server *grpc.Server, tracker *middleware.Tracker) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Printf("Todas as chamadas em andamento foram concluídas")
+	case <-ctx.Done():
+		calls := tracker.InFlight()
+		log.Printf("Prazo de encerramento esgotado com %d chamada(s) em andamento", len(calls))
+		for _, call := range calls {
+			log.Printf("  %s (iniciada há %s)", call.Method, time.Since(call.Started).Round(time.Millisecond))
+		}
+		server.Stop()
+		<-done
+	}
+}
+
+// newAuthInterceptor carrega a política e os autenticadores configurados
+func newAuthInterceptor(cfg *config.Config, lookup auth.Lookup) (*auth.Interceptor, error) {
+	policy, err := auth.LoadPolicy(cfg.Auth.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var authenticators []auth.Authenticator
+	if cfg.Auth.JWKSFile != "" {
+		jwtAuth, err := auth.NewJWTAuthenticator(cfg.Auth.JWKSFile, cfg.Auth.JWTIssuer, cfg.Auth.JWTAudience)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, jwtAuth)
+	}
+	if cfg.TLS.ClientCAFile != "" {
+		authenticators = append(authenticators, auth.CertAuthenticator{})
+	}
+
+	return auth.NewInterceptor(policy, lookup, authenticators...), nil
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	"github.com/spf13/cobra"
+)
+
+// batchSize é o número de itens por chamada ao criar a partir de arquivo
+const batchSize = 1000
+
+var accountColumns = []column[*pb.AccountResponse]{
+	{"ID", func(a *pb.AccountResponse) string { return a.Id }},
+	{"LEDGER", func(a *pb.AccountResponse) string { return strconv.FormatUint(uint64(a.Ledger), 10) }},
+	{"CODE", func(a *pb.AccountResponse) string { return strconv.FormatUint(uint64(a.Code), 10) }},
+	{"FLAGS", func(a *pb.AccountResponse) string { return formatFlags(a.Flags, accountFlags) }},
+	{"DEBITS_POSTED", func(a *pb.AccountResponse) string { return a.DebitsPosted }},
+	{"CREDITS_POSTED", func(a *pb.AccountResponse) string { return a.CreditsPosted }},
+	{"DEBITS_PENDING", func(a *pb.AccountResponse) string { return a.DebitsPending }},
+	{"CREDITS_PENDING", func(a *pb.AccountResponse) string { return a.CreditsPending }},
+	{"BALANCE", func(a *pb.AccountResponse) string { return a.Balance }},
+	{"USER_DATA_128", func(a *pb.AccountResponse) string { return a.UserData_128 }},
+}
+
+var batchColumns = []column[*pb.BatchItemResult]{
+	{"INDEX", func(r *pb.BatchItemResult) string { return strconv.FormatUint(uint64(r.Index), 10) }},
+	{"ID", func(r *pb.BatchItemResult) string { return r.Id }},
+	{"RESULT", func(r *pb.BatchItemResult) string { return r.Result }},
+}
+
+func newAccountCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "account",
+		Short: "Create, read and list accounts",
+	}
+	cmd.AddCommand(
+		newAccountCreateCommand(o),
+		newAccountGetCommand(o),
+		newAccountListCommand(o),
+	)
+	return cmd
+}
+
+func newAccountCreateCommand(o *options) *cobra.Command {
+	req := &pb.CreateAccountRequest{}
+	var flags []string
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an account, or many from a file",
+		Example: `  tbctl account create --ledger 1 --code 10 --flags debits-must-not-exceed-credits,history
+  tbctl account create -f accounts.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file != "" {
+				accounts, err := readBatch(file, func() *pb.CreateAccountRequest { return &pb.CreateAccountRequest{} })
+				if err != nil {
+					return err
+				}
+				batches := chunk(accounts, batchSize, func(a *pb.CreateAccountRequest) bool { return linked(a.Flags) })
+				return createBatch(cmd, o, batches, func(ctx context.Context, client pb.FinancialServiceClient, batch []*pb.CreateAccountRequest) (*pb.BatchResponse, error) {
+					return client.CreateAccountsBatch(ctx, &pb.CreateAccountsBatchRequest{Accounts: batch})
+				})
+			}
+
+			if req.Ledger == 0 || req.Code == 0 {
+				return errors.New("--ledger and --code are required")
+			}
+			var err error
+			if req.Flags, err = parseFlags(flags, accountFlags); err != nil {
+				return err
+			}
+			ctx, cancel, client, err := o.call(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			account, err := client.CreateAccount(ctx, req)
+			if err != nil {
+				return err
+			}
+			return printOne(cmd.OutOrStdout(), o.output, account, accountColumns)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&req.Id, "id", "", "account ID, decimal or UUID (default: generated)")
+	f.Uint32Var(&req.Ledger, "ledger", 0, "ledger of the account")
+	f.Uint32Var(&req.Code, "code", 0, "account code")
+	f.StringVar(&req.UserData_128, "user-data-128", "", "user data, decimal or UUID")
+	f.Uint64Var(&req.UserData_64, "user-data-64", 0, "user data")
+	f.Uint32Var(&req.UserData_32, "user-data-32", 0, "user data")
+	f.StringVarP(&file, "file", "f", "", "create the accounts in a JSON, JSON lines or CSV file (- for stdin)")
+	addFlagsFlag(cmd, &flags, accountFlags)
+	must(cmd.MarkFlagFilename("file", "json", "jsonl", "csv"))
+	cmd.MarkFlagsMutuallyExclusive("file", "ledger")
+	cmd.MarkFlagsMutuallyExclusive("file", "id")
+	return cmd
+}
+
+// createBatch envia os lotes de um arquivo, um por chamada, e imprime o
+// resultado de cada item
+func createBatch[T any](cmd *cobra.Command, o *options, batches [][]T, send func(context.Context, pb.FinancialServiceClient, []T) (*pb.BatchResponse, error)) error {
+	var results []*pb.BatchItemResult
+	var total, failed, notAttempted int
+	var reason string
+	for _, batch := range batches {
+		ctx, cancel, client, err := o.call(cmd)
+		if err != nil {
+			return err
+		}
+		resp, err := send(ctx, client, batch)
+		cancel()
+		if err != nil {
+			if total > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "the first %d items were sent\n", total)
+			}
+			return err
+		}
+		for _, result := range resp.Results {
+			result.Index += uint32(total)
+			results = append(results, result)
+		}
+		total += len(batch)
+		failed += int(resp.Failed)
+		if resp.NotAttempted > 0 {
+			notAttempted = int(resp.NotAttempted)
+			reason = resp.ErrorMessage
+			break
+		}
+	}
+
+	if err := printList(cmd.OutOrStdout(), o.output, results, batchColumns); err != nil {
+		return err
+	}
+	if notAttempted > 0 {
+		return fmt.Errorf("%d items were not attempted and later batches were not sent: %s", notAttempted, reason)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d items failed", failed, total)
+	}
+	return nil
+}
+
+func newAccountGetCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get ID...",
+		Short:             "Show accounts",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			accounts, err := o.getAccounts(cmd, args)
+			if err != nil {
+				return err
+			}
+			if len(accounts) == 1 {
+				return printOne(cmd.OutOrStdout(), o.output, accounts[0], accountColumns)
+			}
+			return printList(cmd.OutOrStdout(), o.output, accounts, accountColumns)
+		},
+	}
+}
+
+func (o *options) getAccounts(cmd *cobra.Command, ids []string) ([]*pb.AccountResponse, error) {
+	accounts := make([]*pb.AccountResponse, len(ids))
+	for i, id := range ids {
+		ctx, cancel, client, err := o.call(cmd)
+		if err != nil {
+			return nil, err
+		}
+		accounts[i], err = client.GetAccount(ctx, &pb.GetAccountRequest{Id: id})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", id, err)
+		}
+	}
+	return accounts, nil
+}
+
+func newAccountListCommand(o *options) *cobra.Command {
+	req := &pb.QueryAccountsRequest{}
+	var page pageFlags
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List accounts by ledger, code or user data",
+		Example: `  tbctl account list --ledger 1 --code 10 --all
+  tbctl account list --user-data-128 0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59 -o csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.TimestampMin = uint64(page.from)
+			req.TimestampMax = uint64(page.to)
+			req.Limit = page.limit
+			req.Reversed = page.reversed
+
+			accounts, err := collect(cmd, &page, func(token string) ([]*pb.AccountResponse, string, error) {
+				ctx, cancel, client, err := o.call(cmd)
+				if err != nil {
+					return nil, "", err
+				}
+				defer cancel()
+				req.PageToken = token
+				resp, err := client.QueryAccounts(ctx, req)
+				if err != nil {
+					return nil, "", err
+				}
+				return resp.Accounts, resp.NextPageToken, nil
+			})
+			if err != nil {
+				return err
+			}
+			return printList(cmd.OutOrStdout(), o.output, accounts, accountColumns)
+		},
+	}
+
+	f := cmd.Flags()
+	f.Uint32Var(&req.Ledger, "ledger", 0, "only accounts of this ledger")
+	f.Uint32Var(&req.Code, "code", 0, "only accounts with this code")
+	f.StringVar(&req.UserData_128, "user-data-128", "", "only accounts with this user data, decimal or UUID")
+	f.Uint64Var(&req.UserData_64, "user-data-64", 0, "only accounts with this user data")
+	f.Uint32Var(&req.UserData_32, "user-data-32", 0, "only accounts with this user data")
+	page.register(cmd)
+	return cmd
+}
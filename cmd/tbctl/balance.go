@@ -0,0 +1,132 @@
+package main
+
+import (
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	"github.com/spf13/cobra"
+)
+
+var balanceColumns = []column[*pb.AccountResponse]{
+	{"ID", func(a *pb.AccountResponse) string { return a.Id }},
+	{"BALANCE", func(a *pb.AccountResponse) string { return a.Balance }},
+	{"DEBITS_POSTED", func(a *pb.AccountResponse) string { return a.DebitsPosted }},
+	{"CREDITS_POSTED", func(a *pb.AccountResponse) string { return a.CreditsPosted }},
+	{"DEBITS_PENDING", func(a *pb.AccountResponse) string { return a.DebitsPending }},
+	{"CREDITS_PENDING", func(a *pb.AccountResponse) string { return a.CreditsPending }},
+}
+
+var historicalBalanceColumns = []column[*pb.AccountBalance]{
+	{"TIMESTAMP", func(b *pb.AccountBalance) string { return formatUint(b.Timestamp) }},
+	{"DEBITS_POSTED", func(b *pb.AccountBalance) string { return b.DebitsPosted }},
+	{"CREDITS_POSTED", func(b *pb.AccountBalance) string { return b.CreditsPosted }},
+	{"DEBITS_PENDING", func(b *pb.AccountBalance) string { return b.DebitsPending }},
+	{"CREDITS_PENDING", func(b *pb.AccountBalance) string { return b.CreditsPending }},
+}
+
+func newBalanceCommand(o *options) *cobra.Command {
+	var at timestampValue
+	cmd := &cobra.Command{
+		Use:   "balance ACCOUNT_ID...",
+		Short: "Show the current balances of accounts, or one account's at a past time",
+		Example: `  tbctl balance 1001 1002
+  tbctl balance 1001 --at 2025-01-31T23:59:59Z`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if at == 0 {
+				accounts, err := o.getAccounts(cmd, args)
+				if err != nil {
+					return err
+				}
+				return printList(cmd.OutOrStdout(), o.output, accounts, balanceColumns)
+			}
+
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			ctx, cancel, client, err := o.call(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			balance, err := client.GetBalanceAt(ctx, &pb.GetBalanceAtRequest{AccountId: args[0], Timestamp: uint64(at)})
+			if err != nil {
+				return err
+			}
+			return printOne(cmd.OutOrStdout(), o.output, balance, historicalBalanceColumns)
+		},
+	}
+	cmd.Flags().Var(&at, "at", "balance at this timestamp, in nanoseconds or RFC 3339 (needs the history flag)")
+	return cmd
+}
+
+func newHistoryCommand(o *options) *cobra.Command {
+	var page pageFlags
+	var balances bool
+
+	cmd := &cobra.Command{
+		Use:   "history ACCOUNT_ID",
+		Short: "List the transfers of an account, or its balance after each",
+		Example: `  tbctl history 1001 --reversed --limit 20
+  tbctl history 1001 --balances --from 2025-01-01T00:00:00Z --all -o csv`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if balances {
+				req := &pb.GetAccountBalancesRequest{
+					AccountId:    args[0],
+					TimestampMin: uint64(page.from),
+					TimestampMax: uint64(page.to),
+					Limit:        page.limit,
+					Reversed:     page.reversed,
+				}
+				items, err := collect(cmd, &page, func(token string) ([]*pb.AccountBalance, string, error) {
+					ctx, cancel, client, err := o.call(cmd)
+					if err != nil {
+						return nil, "", err
+					}
+					defer cancel()
+					req.PageToken = token
+					resp, err := client.GetAccountBalances(ctx, req)
+					if err != nil {
+						return nil, "", err
+					}
+					return resp.Balances, resp.NextPageToken, nil
+				})
+				if err != nil {
+					return err
+				}
+				return printList(cmd.OutOrStdout(), o.output, items, historicalBalanceColumns)
+			}
+
+			req := &pb.ListAccountTransfersRequest{
+				AccountId:    args[0],
+				TimestampMin: uint64(page.from),
+				TimestampMax: uint64(page.to),
+				Limit:        page.limit,
+				Reversed:     page.reversed,
+			}
+			items, err := collect(cmd, &page, func(token string) ([]*pb.TransferResponse, string, error) {
+				ctx, cancel, client, err := o.call(cmd)
+				if err != nil {
+					return nil, "", err
+				}
+				defer cancel()
+				req.PageToken = token
+				resp, err := client.ListAccountTransfers(ctx, req)
+				if err != nil {
+					return nil, "", err
+				}
+				return resp.Transfers, resp.NextPageToken, nil
+			})
+			if err != nil {
+				return err
+			}
+			return printList(cmd.OutOrStdout(), o.output, items, transferColumns)
+		},
+	}
+
+	page.register(cmd)
+	cmd.Flags().BoolVar(&balances, "balances", false, "list the account's balances instead (needs the history flag)")
+	return cmd
+}
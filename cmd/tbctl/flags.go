@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// flagName associa o nome de uma flag do TigerBeetle ao seu bit
+type flagName struct {
+	name string
+	bit  uint16
+}
+
+var accountFlags = []flagName{
+	{"linked", tb_types.AccountFlags{Linked: true}.ToUint16()},
+	{"debits-must-not-exceed-credits", tb_types.AccountFlags{DebitsMustNotExceedCredits: true}.ToUint16()},
+	{"credits-must-not-exceed-debits", tb_types.AccountFlags{CreditsMustNotExceedDebits: true}.ToUint16()},
+	{"history", tb_types.AccountFlags{History: true}.ToUint16()},
+	{"imported", tb_types.AccountFlags{Imported: true}.ToUint16()},
+	{"closed", tb_types.AccountFlags{Closed: true}.ToUint16()},
+}
+
+var transferFlags = []flagName{
+	{"linked", tb_types.TransferFlags{Linked: true}.ToUint16()},
+	{"pending", tb_types.TransferFlags{Pending: true}.ToUint16()},
+	{"post-pending-transfer", tb_types.TransferFlags{PostPendingTransfer: true}.ToUint16()},
+	{"void-pending-transfer", tb_types.TransferFlags{VoidPendingTransfer: true}.ToUint16()},
+	{"balancing-debit", tb_types.TransferFlags{BalancingDebit: true}.ToUint16()},
+	{"balancing-credit", tb_types.TransferFlags{BalancingCredit: true}.ToUint16()},
+	{"closing-debit", tb_types.TransferFlags{ClosingDebit: true}.ToUint16()},
+	{"closing-credit", tb_types.TransferFlags{ClosingCredit: true}.ToUint16()},
+	{"imported", tb_types.TransferFlags{Imported: true}.ToUint16()},
+}
+
+// linked indica se um item forma cadeia com o seguinte; o bit é o mesmo em
+// contas e transferências
+func linked(flags uint32) bool {
+	return flags&uint32(tb_types.AccountFlags{Linked: true}.ToUint16()) != 0
+}
+
+func parseFlags(names []string, known []flagName) (uint32, error) {
+	var flags uint32
+next:
+	for _, name := range names {
+		for _, f := range known {
+			if f.name == name {
+				flags |= uint32(f.bit)
+				continue next
+			}
+		}
+		return 0, fmt.Errorf("unknown flag %q, want one of %s", name, strings.Join(flagNames(known), ", "))
+	}
+	return flags, nil
+}
+
+// formatFlags lista os nomes das flags ligadas, separados por "|"
+func formatFlags(flags uint32, known []flagName) string {
+	var names []string
+	for _, f := range known {
+		if flags&uint32(f.bit) != 0 {
+			names = append(names, f.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+func flagNames(known []flagName) []string {
+	names := make([]string, len(known))
+	for i, f := range known {
+		names[i] = f.name
+	}
+	return names
+}
+
+// addFlagsFlag registra --flags com o complemento dos nomes conhecidos
+func addFlagsFlag(cmd *cobra.Command, names *[]string, known []flagName) {
+	cmd.Flags().StringSliceVar(names, "flags", nil, "comma-separated flags: "+strings.Join(flagNames(known), ", "))
+	must(cmd.RegisterFlagCompletionFunc("flags", cobra.FixedCompletions(flagNames(known), cobra.ShellCompDirectiveNoFileComp)))
+}
+
+// timestampValue é um timestamp do ledger, em nanossegundos desde a época
+// ou em RFC 3339
+type timestampValue uint64
+
+func (t *timestampValue) String() string {
+	if *t == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*t), 10)
+}
+
+func (t *timestampValue) Set(s string) error {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		*t = timestampValue(n)
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil || parsed.UnixNano() <= 0 {
+		return fmt.Errorf("want nanoseconds since the epoch or an RFC 3339 time")
+	}
+	*t = timestampValue(parsed.UnixNano())
+	return nil
+}
+
+func (t *timestampValue) Type() string {
+	return "timestamp"
+}
+
+// pageFlags são as flags de paginação das listagens
+type pageFlags struct {
+	from, to  timestampValue
+	limit     uint32
+	reversed  bool
+	all       bool
+	pageToken string
+}
+
+func (p *pageFlags) register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.Var(&p.from, "from", "oldest timestamp, in nanoseconds or RFC 3339")
+	flags.Var(&p.to, "to", "newest timestamp, in nanoseconds or RFC 3339")
+	flags.Uint32Var(&p.limit, "limit", 0, "results per page (default: the service's)")
+	flags.BoolVar(&p.reversed, "reversed", false, "newest first")
+	flags.BoolVar(&p.all, "all", false, "fetch every page")
+	flags.StringVar(&p.pageToken, "page-token", "", "continue from a previous listing")
+}
+
+// collect busca uma página, ou todas com --all, e avisa no stderr quando
+// há mais resultados
+func collect[T any](cmd *cobra.Command, p *pageFlags, fetch func(token string) ([]T, string, error)) ([]T, error) {
+	var items []T
+	token := p.pageToken
+	for {
+		page, next, err := fetch(token)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		token = next
+		if token == "" || !p.all {
+			break
+		}
+	}
+	if token != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "more results: --page-token %s\n", token)
+	}
+	return items, nil
+}
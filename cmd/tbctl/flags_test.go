@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		known   []flagName
+		want    uint32
+		wantErr string
+	}{
+		{name: "none", known: accountFlags},
+		{
+			name:  "account",
+			names: []string{"history", "debits-must-not-exceed-credits"},
+			known: accountFlags,
+			want:  uint32(tb_types.AccountFlags{History: true, DebitsMustNotExceedCredits: true}.ToUint16()),
+		},
+		{
+			name:  "transfer",
+			names: []string{"pending", "linked"},
+			known: transferFlags,
+			want:  uint32(tb_types.TransferFlags{Pending: true, Linked: true}.ToUint16()),
+		},
+		{
+			name:    "transfer flag on an account",
+			names:   []string{"pending"},
+			known:   accountFlags,
+			wantErr: `unknown flag "pending"`,
+		},
+		{
+			name:    "case matters",
+			names:   []string{"History"},
+			known:   accountFlags,
+			wantErr: "want one of linked, debits-must-not-exceed-credits",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFlags(tt.names, tt.known)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, linked(got) == (tt.want&1 != 0))
+		})
+	}
+}
+
+func TestFormatFlags(t *testing.T) {
+	flags := uint32(tb_types.TransferFlags{Pending: true, BalancingCredit: true}.ToUint16())
+	assert.Equal(t, "pending|balancing-credit", formatFlags(flags, transferFlags))
+	assert.Equal(t, "", formatFlags(0, transferFlags))
+
+	// Parsing what was formatted gives the same bits
+	names := []string{"pending", "balancing-credit"}
+	parsed, err := parseFlags(names, transferFlags)
+	require.NoError(t, err)
+	assert.Equal(t, flags, parsed)
+}
+
+func TestTimestampValue(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    uint64
+		wantErr bool
+	}{
+		{in: "1700000000000000000", want: 1700000000000000000},
+		{in: "2024-01-02T03:04:05Z", want: uint64(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixNano())},
+		{in: "2024-01-02T03:04:05.5-03:00", want: uint64(time.Date(2024, 1, 2, 6, 4, 5, 5e8, time.UTC).UnixNano())},
+		{in: "1960-01-01T00:00:00Z", wantErr: true},
+		{in: "yesterday", wantErr: true},
+		{in: "-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var v timestampValue
+			err := v.Set(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, uint64(v))
+		})
+	}
+
+	var zero timestampValue
+	assert.Equal(t, "", zero.String())
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// readBatch lê as requisições de um arquivo ("-" é a entrada padrão). O
+// arquivo pode ser um array JSON, um objeto JSON por linha, ou CSV com os
+// nomes dos campos no cabeçalho, como em debit_account_id,amount.
+func readBatch[T proto.Message](path string, newItem func() T) ([]T, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []json.RawMessage
+	switch trimmed := bytes.TrimSpace(data); {
+	case strings.EqualFold(filepath.Ext(path), ".csv"):
+		objects, err = csvObjects(data)
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		err = json.Unmarshal(trimmed, &objects)
+	default:
+		objects, err = jsonLines(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	items := make([]T, len(objects))
+	for i, object := range objects {
+		items[i] = newItem()
+		if err := protojson.Unmarshal(object, items[i]); err != nil {
+			return nil, fmt.Errorf("%s: record %d: %w", path, i+1, err)
+		}
+	}
+	return items, nil
+}
+
+func jsonLines(data []byte) ([]json.RawMessage, error) {
+	var objects []json.RawMessage
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if !json.Valid(text) {
+			return nil, fmt.Errorf("line %d: invalid JSON", line)
+		}
+		objects = append(objects, json.RawMessage(bytes.Clone(text)))
+	}
+	return objects, scanner.Err()
+}
+
+// csvObjects converte cada linha num objeto JSON; os valores vão como
+// strings, que o protojson aceita também para campos numéricos
+func csvObjects(data []byte) ([]json.RawMessage, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	objects := make([]json.RawMessage, 0, len(records)-1)
+	for _, record := range records[1:] {
+		object := make(map[string]string, len(header))
+		for i, name := range header {
+			if record[i] != "" {
+				object[strings.TrimSpace(name)] = record[i]
+			}
+		}
+		raw, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, raw)
+	}
+	return objects, nil
+}
+
+// chunk divide uma requisição em lotes de pelo menos size itens, cortando
+// só depois de um item que não seja linked para não separar uma cadeia
+func chunk[T any](items []T, size int, linked func(T) bool) [][]T {
+	var batches [][]T
+	start := 0
+	for i, item := range items {
+		if i+1-start >= size && !linked(item) {
+			batches = append(batches, items[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(items) {
+		batches = append(batches, items[start:])
+	}
+	return batches
+}
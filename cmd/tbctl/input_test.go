@@ -0,0 +1,126 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBatch(t *testing.T) {
+	dir := t.TempDir()
+	newTransfer := func() *pb.CreateTransferRequest { return &pb.CreateTransferRequest{} }
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []*pb.CreateTransferRequest
+		wantErr string
+	}{
+		{
+			name: "json array",
+			file: "transfers.json",
+			content: `[
+				{"debit_account_id": "1", "credit_account_id": "2", "amount": "10", "ledger": 1},
+				{"debitAccountId": "2", "creditAccountId": "1", "amount": "5", "ledger": 1}
+			]`,
+			want: []*pb.CreateTransferRequest{
+				{DebitAccountId: "1", CreditAccountId: "2", Amount: "10", Ledger: 1},
+				{DebitAccountId: "2", CreditAccountId: "1", Amount: "5", Ledger: 1},
+			},
+		},
+		{
+			name:    "json lines",
+			file:    "transfers.jsonl",
+			content: "{\"amount\": \"10\", \"flags\": 1}\n\n{\"amount\": \"20\"}\n",
+			want: []*pb.CreateTransferRequest{
+				{Amount: "10", Flags: 1},
+				{Amount: "20"},
+			},
+		},
+		{
+			name:    "csv",
+			file:    "transfers.CSV",
+			content: "debit_account_id, amount,ledger,code\n1,10,1,7\n2,,1,\n",
+			want: []*pb.CreateTransferRequest{
+				{DebitAccountId: "1", Amount: "10", Ledger: 1, Code: "7"},
+				{DebitAccountId: "2", Ledger: 1},
+			},
+		},
+		{
+			name:    "empty csv",
+			file:    "empty.csv",
+			content: "",
+			want:    []*pb.CreateTransferRequest{},
+		},
+		{
+			name:    "invalid json line",
+			file:    "bad.jsonl",
+			content: "{\"amount\": \"10\"}\n{amount\n",
+			wantErr: "line 2: invalid JSON",
+		},
+		{
+			name:    "unknown field",
+			file:    "unknown.json",
+			content: `[{"amount": "10"}, {"value": "10"}]`,
+			wantErr: "record 2",
+		},
+		{
+			name:    "ragged csv",
+			file:    "ragged.csv",
+			content: "amount,ledger\n10\n",
+			wantErr: "wrong number of fields",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			got, err := readBatch(path, newTransfer)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].String(), got[i].String(), "record %d", i)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readBatch(filepath.Join(dir, "missing.json"), newTransfer)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestChunk(t *testing.T) {
+	// true marks an item linked to the next one
+	tests := []struct {
+		name   string
+		linked []bool
+		size   int
+		want   []int
+	}{
+		{name: "empty", size: 2, want: nil},
+		{name: "even", linked: []bool{false, false, false, false}, size: 2, want: []int{2, 2}},
+		{name: "remainder", linked: []bool{false, false, false}, size: 2, want: []int{2, 1}},
+		{name: "chain kept whole", linked: []bool{false, true, true, false, false}, size: 2, want: []int{4, 1}},
+		{name: "chain at the end", linked: []bool{false, false, true, true}, size: 2, want: []int{2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sizes []int
+			for _, batch := range chunk(tt.linked, tt.size, func(linked bool) bool { return linked }) {
+				sizes = append(sizes, len(batch))
+			}
+			assert.Equal(t, tt.want, sizes)
+		})
+	}
+}
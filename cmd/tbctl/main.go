@@ -0,0 +1,173 @@
+// tbctl é o cliente de linha de comando do serviço financeiro, para
+// operadores consultarem e movimentarem contas sem grpcurl
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// options são as flags comuns a todos os comandos
+type options struct {
+	address  string
+	token    string
+	insecure bool
+	timeout  time.Duration
+	output   string
+
+	tls        bool
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverName string
+
+	conn   *grpc.ClientConn
+	client pb.FinancialServiceClient
+}
+
+func newRootCommand() *cobra.Command {
+	o := &options{}
+	root := &cobra.Command{
+		Use:          "tbctl",
+		Short:        "Operate accounts and transfers of the financial service",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkFormat(o.output)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if o.conn != nil {
+				o.conn.Close()
+			}
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVarP(&o.address, "address", "a", envOr("TBCTL_ADDRESS", "localhost:50051"), "service address (env TBCTL_ADDRESS)")
+	flags.StringVar(&o.token, "token", os.Getenv("TBCTL_TOKEN"), "bearer token sent with every call (env TBCTL_TOKEN)")
+	flags.BoolVar(&o.insecure, "insecure", false, "allow sending --token over a connection without TLS")
+	flags.DurationVar(&o.timeout, "timeout", 10*time.Second, "deadline of each call")
+	flags.StringVarP(&o.output, "output", "o", "table", "output format: table, json or csv")
+	flags.BoolVar(&o.tls, "tls", false, "connect with TLS")
+	flags.StringVar(&o.caFile, "tls-ca", "", "PEM file of the CA that signed the server certificate (default: system roots)")
+	flags.StringVar(&o.certFile, "tls-cert", "", "PEM client certificate, for mutual TLS")
+	flags.StringVar(&o.keyFile, "tls-key", "", "PEM key of the client certificate")
+	flags.StringVar(&o.serverName, "tls-server-name", "", "server name to verify, if not the address host")
+
+	must(root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp)))
+	for _, name := range []string{"tls-ca", "tls-cert", "tls-key"} {
+		must(root.MarkPersistentFlagFilename(name, "pem", "crt", "key"))
+	}
+
+	root.AddCommand(
+		newAccountCommand(o),
+		newTransferCommand(o),
+		newBalanceCommand(o),
+		newHistoryCommand(o),
+	)
+	return root
+}
+
+// must interrompe a montagem dos comandos quando uma anotação cita uma flag
+// que não existe, um erro de programação
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+// dial conecta ao serviço na primeira chamada; a conexão é fechada ao fim
+// do comando
+func (o *options) dial() (pb.FinancialServiceClient, error) {
+	if o.client != nil {
+		return o.client, nil
+	}
+
+	// Sem TLS o token iria em texto claro; só com --insecure explícito
+	useTLS := o.tls || o.caFile != "" || o.certFile != ""
+	if o.token != "" && !useTLS && !o.insecure {
+		return nil, errors.New("--token needs a TLS connection (--tls, --tls-ca or --tls-cert); pass --insecure to send it in cleartext")
+	}
+
+	creds := insecure.NewCredentials()
+	if useTLS {
+		cfg, err := o.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+
+	conn, err := grpc.NewClient(o.address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", o.address, err)
+	}
+	o.conn = conn
+	o.client = pb.NewFinancialServiceClient(conn)
+	return o.client, nil
+}
+
+func (o *options) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: o.serverName}
+
+	if o.caFile != "" {
+		pem, err := os.ReadFile(o.caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no certificates found", o.caFile)
+		}
+	}
+
+	if o.certFile != "" || o.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.certFile, o.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// call devolve o cliente e o contexto de uma chamada, com o timeout e o
+// token de autenticação
+func (o *options) call(cmd *cobra.Command) (context.Context, context.CancelFunc, pb.FinancialServiceClient, error) {
+	client, err := o.dial()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ctx := cmd.Context()
+	if o.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+o.token)
+	}
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	return ctx, cancel, client, nil
+}
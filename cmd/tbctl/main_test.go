@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/service"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// startServer serves the financial service on an in-memory ledger and
+// returns its address
+func startServer(t *testing.T) string {
+	t.Helper()
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+
+	server := grpc.NewServer()
+	pb.RegisterFinancialServiceServer(server, service.NewFinancialService(repo))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+// run executes tbctl against address and returns what it wrote to stdout
+func run(t *testing.T, address string, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	root := newRootCommand()
+	root.SetArgs(append([]string{"--address", address}, args...))
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	err := root.ExecuteContext(context.Background())
+	return stdout.String(), err
+}
+
+func TestCommands(t *testing.T) {
+	address := startServer(t)
+
+	out, err := run(t, address, "account", "create", "--id", "1001", "--ledger", "1", "--code", "10", "-o", "json")
+	require.NoError(t, err)
+	var account map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &account))
+	assert.Equal(t, "1001", account["id"])
+	assert.Equal(t, float64(10), account["code"])
+
+	_, err = run(t, address, "account", "create", "--id", "1002", "--ledger", "1", "--code", "10", "--flags", "history")
+	require.NoError(t, err)
+
+	_, err = run(t, address, "transfer", "create", "--id", "2001", "--debit", "1001", "--credit", "1002",
+		"--amount", "250", "--ledger", "1", "--code", "1")
+	require.NoError(t, err)
+
+	t.Run("transfer get", func(t *testing.T) {
+		out, err := run(t, address, "transfer", "get", "2001", "-o", "csv")
+		require.NoError(t, err)
+		lines := bytes.Split(bytes.TrimSpace([]byte(out)), []byte("\n"))
+		require.Len(t, lines, 2)
+		assert.Equal(t, "id,debit_account,credit_account,amount,ledger,code,flags,pending_id,timestamp", string(lines[0]))
+		assert.Contains(t, string(lines[1]), "2001,1001,1002,250,1,1,,,")
+	})
+
+	t.Run("account get shows the balance", func(t *testing.T) {
+		out, err := run(t, address, "account", "get", "1002")
+		require.NoError(t, err)
+		assert.Contains(t, out, "history")
+		assert.Contains(t, out, "250")
+	})
+
+	t.Run("batch from a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "accounts.csv")
+		require.NoError(t, os.WriteFile(path, []byte("id,ledger,code\n1003,1,10\n1001,1,10\n1002,1,11\n"), 0o600))
+
+		out, err := run(t, address, "account", "create", "-f", path, "-o", "csv")
+		assert.ErrorContains(t, err, "1 of 3 items failed")
+		assert.Contains(t, out, "0,1003,ok")
+		assert.Contains(t, out, "1,1001,exists")
+		assert.Contains(t, out, "2,1002,exists_with_different_flags")
+	})
+
+	t.Run("missing transfer", func(t *testing.T) {
+		_, err := run(t, address, "transfer", "get", "9999")
+		assert.ErrorContains(t, err, "transfer 9999")
+	})
+
+	t.Run("unknown output format", func(t *testing.T) {
+		_, err := run(t, address, "account", "get", "1001", "-o", "yaml")
+		assert.ErrorContains(t, err, "unknown output format")
+	})
+
+	t.Run("token needs TLS", func(t *testing.T) {
+		_, err := run(t, address, "--token", "secret", "account", "get", "1001")
+		assert.ErrorContains(t, err, "--insecure")
+
+		_, err = run(t, address, "--token", "secret", "--insecure", "account", "get", "1001")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var formats = []string{"table", "json", "csv"}
+
+func checkFormat(format string) error {
+	for _, f := range formats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q, want one of %s", format, strings.Join(formats, ", "))
+}
+
+// column é uma coluna das saídas table e csv
+type column[T any] struct {
+	name  string
+	value func(T) string
+}
+
+var jsonOptions = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// printList escreve as mensagens no formato escolhido; em JSON, como um
+// array
+func printList[T proto.Message](w io.Writer, format string, items []T, columns []column[T]) error {
+	switch format {
+	case "json":
+		raw := make([]json.RawMessage, len(items))
+		for i, item := range items {
+			b, err := jsonOptions.Marshal(item)
+			if err != nil {
+				return err
+			}
+			raw[i] = b
+		}
+		out, err := json.MarshalIndent(raw, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", out)
+		return err
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = strings.ToLower(c.name)
+		}
+		cw.Write(header)
+		for _, item := range items {
+			cw.Write(row(item, columns))
+		}
+		cw.Flush()
+		return cw.Error()
+
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = c.name
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, item := range items {
+			fmt.Fprintln(tw, strings.Join(row(item, columns), "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// printOne escreve uma única mensagem; em JSON, como um objeto
+func printOne[T proto.Message](w io.Writer, format string, item T, columns []column[T]) error {
+	if format != "json" {
+		return printList(w, format, []T{item}, columns)
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true, Multiline: true}.Marshal(item)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+func row[T any](item T, columns []column[T]) []string {
+	values := make([]string, len(columns))
+	for i, c := range columns {
+		values[i] = c.value(item)
+	}
+	return values
+}
+
+func formatUint(v uint64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatUint(v, 10)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{format: "table"},
+		{format: "json"},
+		{format: "csv"},
+		{format: "yaml", wantErr: true},
+		{format: "", wantErr: true},
+		{format: "JSON", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			err := checkFormat(tt.format)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "table, json, csv")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	columns := []column[*pb.AccountResponse]{
+		{"ID", func(a *pb.AccountResponse) string { return a.Id }},
+		{"LEDGER", func(a *pb.AccountResponse) string { return strconv.FormatUint(uint64(a.Ledger), 10) }},
+		{"FLAGS", func(a *pb.AccountResponse) string { return formatFlags(a.Flags, accountFlags) }},
+	}
+	accounts := []*pb.AccountResponse{
+		{Id: "1001", Ledger: 1, Flags: 1<<1 | 1<<3},
+		{Id: "1002", Ledger: 700},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		items  []*pb.AccountResponse
+		want   string
+	}{
+		{
+			name:   "table",
+			format: "table",
+			items:  accounts,
+			want: "ID    LEDGER  FLAGS\n" +
+				"1001  1       debits-must-not-exceed-credits|history\n" +
+				"1002  700     \n",
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			items:  accounts,
+			want:   "id,ledger,flags\n1001,1,debits-must-not-exceed-credits|history\n1002,700,\n",
+		},
+		{
+			name:   "empty table",
+			format: "table",
+			want:   "ID  LEDGER  FLAGS\n",
+		},
+		{
+			name:   "empty json",
+			format: "json",
+			want:   "[]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, printList(&buf, tt.format, tt.items, columns))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	// protojson varies its spacing on purpose, so JSON is compared decoded
+	t.Run("json list", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printList(&buf, "json", accounts, columns))
+		var got []map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Len(t, got, 2)
+		// Proto field names, with unset fields included
+		assert.Equal(t, "1002", got[1]["id"])
+		assert.Equal(t, "", got[1]["user_data_128"])
+	})
+
+	t.Run("json object", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printOne(&buf, "json", accounts[1], columns))
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		assert.Equal(t, float64(700), got["ledger"])
+	})
+
+	t.Run("one row in table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, printOne(&buf, "table", accounts[1], columns))
+		assert.Equal(t, "ID    LEDGER  FLAGS\n1002  700     \n", buf.String())
+	})
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	"github.com/spf13/cobra"
+)
+
+var transferColumns = []column[*pb.TransferResponse]{
+	{"ID", func(t *pb.TransferResponse) string { return t.Id }},
+	{"DEBIT_ACCOUNT", func(t *pb.TransferResponse) string { return t.DebitAccountId }},
+	{"CREDIT_ACCOUNT", func(t *pb.TransferResponse) string { return t.CreditAccountId }},
+	{"AMOUNT", func(t *pb.TransferResponse) string { return t.Amount }},
+	{"LEDGER", func(t *pb.TransferResponse) string { return strconv.FormatUint(uint64(t.Ledger), 10) }},
+	{"CODE", func(t *pb.TransferResponse) string { return t.Code }},
+	{"FLAGS", func(t *pb.TransferResponse) string { return formatFlags(t.Flags, transferFlags) }},
+	{"PENDING_ID", func(t *pb.TransferResponse) string { return t.PendingId }},
+	{"TIMESTAMP", func(t *pb.TransferResponse) string { return formatUint(t.Timestamp) }},
+}
+
+func newTransferCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "Create, read and resolve transfers",
+	}
+	cmd.AddCommand(
+		newTransferCreateCommand(o),
+		newTransferGetCommand(o),
+		newTransferPostCommand(o),
+		newTransferVoidCommand(o),
+	)
+	return cmd
+}
+
+func newTransferCreateCommand(o *options) *cobra.Command {
+	req := &pb.CreateTransferRequest{}
+	var flags []string
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a transfer, or many from a file",
+		Example: `  tbctl transfer create --debit 1001 --credit 1002 --amount 250 --ledger 1 --code 1
+  tbctl transfer create --debit 1001 --credit 1002 --amount 250 --ledger 1 --code 1 --flags pending --pending-timeout 3600
+  tbctl transfer create -f transfers.jsonl`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file != "" {
+				transfers, err := readBatch(file, func() *pb.CreateTransferRequest { return &pb.CreateTransferRequest{} })
+				if err != nil {
+					return err
+				}
+				batches := chunk(transfers, batchSize, func(t *pb.CreateTransferRequest) bool { return linked(t.Flags) })
+				return createBatch(cmd, o, batches, func(ctx context.Context, client pb.FinancialServiceClient, batch []*pb.CreateTransferRequest) (*pb.BatchResponse, error) {
+					return client.CreateTransfersBatch(ctx, &pb.CreateTransfersBatchRequest{Transfers: batch})
+				})
+			}
+
+			var err error
+			if req.Flags, err = parseFlags(flags, transferFlags); err != nil {
+				return err
+			}
+			if req.PendingId == "" && (req.DebitAccountId == "" || req.CreditAccountId == "" || req.Amount == "") {
+				return errors.New("--debit, --credit and --amount are required")
+			}
+			ctx, cancel, client, err := o.call(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			transfer, err := client.CreateTransfer(ctx, req)
+			if err != nil {
+				return err
+			}
+			return printOne(cmd.OutOrStdout(), o.output, transfer, transferColumns)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&req.Id, "id", "", "transfer ID, decimal or UUID (default: generated)")
+	f.StringVar(&req.DebitAccountId, "debit", "", "account to debit")
+	f.StringVar(&req.CreditAccountId, "credit", "", "account to credit")
+	f.StringVar(&req.Amount, "amount", "", "amount, up to 2^128-1")
+	f.Uint32Var(&req.Ledger, "ledger", 0, "ledger of the transfer")
+	f.StringVar(&req.Code, "code", "", "transfer code")
+	f.StringVar(&req.PendingId, "pending-id", "", "pending transfer posted or voided by this one")
+	f.Uint32Var(&req.Timeout, "pending-timeout", 0, "seconds until a pending transfer expires (default: never)")
+	f.StringVar(&req.UserData_128, "user-data-128", "", "user data, decimal or UUID")
+	f.Uint64Var(&req.UserData_64, "user-data-64", 0, "user data")
+	f.Uint32Var(&req.UserData_32, "user-data-32", 0, "user data")
+	f.StringVarP(&file, "file", "f", "", "create the transfers in a JSON, JSON lines or CSV file (- for stdin)")
+	addFlagsFlag(cmd, &flags, transferFlags)
+	must(cmd.MarkFlagFilename("file", "json", "jsonl", "csv"))
+	cmd.MarkFlagsMutuallyExclusive("file", "debit")
+	cmd.MarkFlagsMutuallyExclusive("file", "id")
+	return cmd
+}
+
+func newTransferGetCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get ID...",
+		Short:             "Show transfers",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			transfers := make([]*pb.TransferResponse, len(args))
+			for i, id := range args {
+				ctx, cancel, client, err := o.call(cmd)
+				if err != nil {
+					return err
+				}
+				transfers[i], err = client.GetTransfer(ctx, &pb.GetTransferRequest{Id: id})
+				cancel()
+				if err != nil {
+					return fmt.Errorf("transfer %s: %w", id, err)
+				}
+			}
+			if len(transfers) == 1 {
+				return printOne(cmd.OutOrStdout(), o.output, transfers[0], transferColumns)
+			}
+			return printList(cmd.OutOrStdout(), o.output, transfers, transferColumns)
+		},
+	}
+}
+
+func newTransferPostCommand(o *options) *cobra.Command {
+	req := &pb.CapturePendingRequest{}
+	cmd := &cobra.Command{
+		Use:   "post PENDING_ID",
+		Short: "Post a pending transfer, in full or in part",
+		Example: `  tbctl transfer post 2001
+  tbctl transfer post 2001 --amount 100`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.PendingId = args[0]
+			ctx, cancel, client, err := o.call(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			transfer, err := client.CapturePending(ctx, req)
+			if err != nil {
+				return err
+			}
+			return printOne(cmd.OutOrStdout(), o.output, transfer, transferColumns)
+		},
+	}
+	cmd.Flags().StringVar(&req.Id, "id", "", "ID of the posting transfer (default: generated)")
+	cmd.Flags().StringVar(&req.Amount, "amount", "", "amount to post (default: the pending amount)")
+	return cmd
+}
+
+func newTransferVoidCommand(o *options) *cobra.Command {
+	req := &pb.VoidPendingRequest{}
+	cmd := &cobra.Command{
+		Use:               "void PENDING_ID",
+		Short:             "Void a pending transfer",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.PendingId = args[0]
+			ctx, cancel, client, err := o.call(cmd)
+			if err != nil {
+				return err
+			}
+			defer cancel()
+			transfer, err := client.VoidPending(ctx, req)
+			if err != nil {
+				return err
+			}
+			return printOne(cmd.OutOrStdout(), o.output, transfer, transferColumns)
+		},
+	}
+	cmd.Flags().StringVar(&req.Id, "id", "", "ID of the voiding transfer (default: generated)")
+	return cmd
+}
@@ -0,0 +1,313 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/auth"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// issuer signs tokens with a key published in a JWKS file
+type issuer struct {
+	key  *ecdsa.PrivateKey
+	jwks string
+}
+
+func newIssuer(t *testing.T) *issuer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "k1", Algorithm: string(jose.ES256), Use: "sig"}}}
+	raw, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+	return &issuer{key: key, jwks: path}
+}
+
+func (i *issuer) token(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: i.key},
+		(&jose.SignerOptions{}).WithHeader("kid", "k1"))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func bearer(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func validClaims(subject string) jwt.Claims {
+	return jwt.Claims{
+		Subject:  subject,
+		Issuer:   "https://auth.example.com",
+		Audience: jwt.Audience{"tigerbeetle-service"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	iss := newIssuer(t)
+	authenticator, err := auth.NewJWTAuthenticator(iss.jwks, "https://auth.example.com", "tigerbeetle-service")
+	require.NoError(t, err)
+
+	t.Run("valid token", func(t *testing.T) {
+		id, err := authenticator.Authenticate(bearer(iss.token(t, validClaims("payouts"))))
+		require.NoError(t, err)
+		assert.Equal(t, &auth.Identity{Subject: "payouts", Method: "jwt"}, id)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		_, err := authenticator.Authenticate(context.Background())
+		assert.ErrorIs(t, err, auth.ErrNoCredentials)
+	})
+
+	invalid := map[string]func() string{
+		"expired": func() string {
+			claims := validClaims("payouts")
+			claims.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+			return iss.token(t, claims)
+		},
+		"wrong audience": func() string {
+			claims := validClaims("payouts")
+			claims.Audience = jwt.Audience{"other"}
+			return iss.token(t, claims)
+		},
+		"unknown key": func() string {
+			return newIssuer(t).token(t, validClaims("payouts"))
+		},
+		"no expiry": func() string {
+			claims := validClaims("payouts")
+			claims.Expiry = nil
+			return iss.token(t, claims)
+		},
+		"garbage": func() string { return "not-a-jwt" },
+	}
+	for name, token := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := authenticator.Authenticate(bearer(token()))
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, auth.ErrNoCredentials)
+		})
+	}
+}
+
+func TestCertAuthenticator(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "payouts"}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tlsState(cert)},
+	})
+
+	id, err := auth.CertAuthenticator{}.Authenticate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &auth.Identity{Subject: "payouts", Method: "mtls"}, id)
+
+	_, err = auth.CertAuthenticator{}.Authenticate(context.Background())
+	assert.ErrorIs(t, err, auth.ErrNoCredentials)
+}
+
+func TestLoadPolicy(t *testing.T) {
+	write := func(content string) string {
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	policy, err := auth.LoadPolicy(write(`
+rules:
+  - subject: payouts
+    methods: [CreateTransfer]
+    ledgers: [2]
+    account_codes: [100]
+`))
+	require.NoError(t, err)
+	rule, ok := policy.Rule("payouts")
+	require.True(t, ok)
+	assert.True(t, rule.AllowsMethod("/financial.FinancialService/CreateTransfer"))
+	assert.False(t, rule.AllowsMethod("/financial.FinancialService/CreateAccount"))
+	assert.True(t, rule.AllowsLedger(2))
+	assert.False(t, rule.AllowsAccountCode(1))
+
+	_, err = auth.LoadPolicy(write("rules:\n  - subject: a\n    methods: [\"*\"]\n  - subject: a\n    methods: [\"*\"]\n"))
+	assert.ErrorContains(t, err, "duplicate subject")
+
+	_, err = auth.LoadPolicy(write("rules:\n  - subject: a\n    method: [\"*\"]\n"))
+	assert.Error(t, err)
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInMemoryRepository()
+	defer repo.Close()
+
+	account := func(ledger uint32, code uint16) string {
+		a := tb_types.Account{ID: tb_types.ID(), UserData128: tb_types.ToUint128(1), Ledger: ledger, Code: code}
+		_, err := repo.CreateAccount(ctx, a)
+		require.NoError(t, err)
+		return tbutil.Uint128ToString(a.ID)
+	}
+	payoutsDebit := account(2, 100)
+	payoutsCredit := account(2, 200)
+	otherDebit := account(2, 200)
+	otherLedger := account(1, 100)
+
+	pending := tb_types.Transfer{
+		ID: tb_types.ID(), DebitAccountID: mustParse(t, otherDebit), CreditAccountID: mustParse(t, payoutsCredit),
+		Amount: tb_types.ToUint128(1), Ledger: 2, Code: 1, Flags: tb_types.TransferFlags{Pending: true}.ToUint16(),
+	}
+	_, err := repo.CreateTransfer(ctx, pending)
+	require.NoError(t, err)
+
+	policy := &auth.Policy{Rules: []auth.Rule{
+		{Subject: "payouts", Methods: []string{"CreateTransfer", "VoidPending", "GetAccount", "QueryAccounts", "QueryTransfers", "ImportAccounts"}, Ledgers: []uint32{2}, AccountCodes: []uint16{100}},
+		{Subject: "admin", Methods: []string{"*"}},
+	}}
+	iss := newIssuer(t)
+	jwtAuth, err := auth.NewJWTAuthenticator(iss.jwks, "", "")
+	require.NoError(t, err)
+	interceptor := auth.NewInterceptor(policy, repo, jwtAuth, auth.CertAuthenticator{}).UnaryInterceptor()
+
+	call := func(ctx context.Context, method string, req any) (*auth.Identity, error) {
+		var seen *auth.Identity
+		info := &grpc.UnaryServerInfo{FullMethod: "/financial.FinancialService/" + method}
+		_, err := interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			seen, _ = auth.FromContext(ctx)
+			return nil, nil
+		})
+		return seen, err
+	}
+	payouts := bearer(iss.token(t, jwt.Claims{Subject: "payouts", Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))}))
+	transfer := func(debit string, ledger uint32) *pb.CreateTransferRequest {
+		return &pb.CreateTransferRequest{DebitAccountId: debit, CreditAccountId: payoutsCredit, Amount: "1", Ledger: ledger, Code: "1"}
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    any
+		code   codes.Code
+	}{
+		{"allowed debit", payouts, "CreateTransfer", transfer(payoutsDebit, 2), codes.OK},
+		{"other account code", payouts, "CreateTransfer", transfer(otherDebit, 2), codes.PermissionDenied},
+		{"other ledger", payouts, "CreateTransfer", transfer(otherLedger, 1), codes.PermissionDenied},
+		{"account on other ledger", payouts, "CreateTransfer", transfer(otherLedger, 2), codes.PermissionDenied},
+		{"method not granted", payouts, "CreateAccount", &pb.CreateAccountRequest{Ledger: 2, Code: 100}, codes.PermissionDenied},
+		{"reading an allowed account", payouts, "GetAccount", &pb.GetAccountRequest{Id: payoutsDebit}, codes.OK},
+		{"reading another account", payouts, "GetAccount", &pb.GetAccountRequest{Id: otherDebit}, codes.PermissionDenied},
+		{"unknown account is left to the service", payouts, "GetAccount", &pb.GetAccountRequest{Id: "424242"}, codes.OK},
+		{"pending transfer debiting another account", payouts, "VoidPending", &pb.VoidPendingRequest{PendingId: tbutil.Uint128ToString(pending.ID)}, codes.PermissionDenied},
+		{"query on an allowed ledger and code", payouts, "QueryAccounts", &pb.QueryAccountsRequest{Ledger: 2, Code: 100}, codes.OK},
+		{"query across ledgers", payouts, "QueryAccounts", &pb.QueryAccountsRequest{Code: 100}, codes.PermissionDenied},
+		{"query across account codes", payouts, "QueryAccounts", &pb.QueryAccountsRequest{Ledger: 2}, codes.PermissionDenied},
+		{"transfer query with account codes", payouts, "QueryTransfers", &pb.QueryTransfersRequest{Ledger: 2}, codes.PermissionDenied},
+		{"request type without a scope", payouts, "GetAccount", &pb.ImportSummary{}, codes.PermissionDenied},
+		{"unrestricted subject", bearer(iss.token(t, validClaims("admin"))), "CreateTransfer", transfer(otherLedger, 1), codes.OK},
+		{"subject without rule", bearer(iss.token(t, validClaims("intruder"))), "GetAccount", &pb.GetAccountRequest{Id: payoutsDebit}, codes.PermissionDenied},
+		{"no credentials", ctx, "GetAccount", &pb.GetAccountRequest{Id: payoutsDebit}, codes.Unauthenticated},
+		{"invalid token", bearer("garbage"), "GetAccount", &pb.GetAccountRequest{Id: payoutsDebit}, codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := call(tt.ctx, tt.method, tt.req)
+			assert.Equal(t, tt.code, status.Code(err), "%v", err)
+			if tt.code == codes.OK {
+				require.NotNil(t, id)
+			}
+		})
+	}
+
+	t.Run("client certificate", func(t *testing.T) {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "payouts"}}
+		ctx := peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: tlsState(cert)}})
+		id, err := call(ctx, "GetAccount", &pb.GetAccountRequest{Id: payoutsDebit})
+		require.NoError(t, err)
+		assert.Equal(t, "mtls", id.Method)
+	})
+
+	t.Run("streamed messages", func(t *testing.T) {
+		stream := &recordStream{ctx: payouts, records: []*pb.ImportAccountRecord{
+			{Account: &pb.CreateAccountRequest{Ledger: 2, Code: 100}, Timestamp: 1},
+			{Account: &pb.CreateAccountRequest{Ledger: 2, Code: 200}, Timestamp: 2},
+		}}
+		var received int
+		info := &grpc.StreamServerInfo{FullMethod: "/financial.FinancialService/ImportAccounts", IsClientStream: true}
+		err := auth.NewInterceptor(policy, repo, jwtAuth).StreamInterceptor()(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+			for {
+				if err := ss.RecvMsg(&pb.ImportAccountRecord{}); err != nil {
+					return err
+				}
+				received++
+			}
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "%v", err)
+		assert.Equal(t, 1, received)
+	})
+
+	t.Run("health checks are public", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) { return nil, nil })
+		assert.NoError(t, err)
+	})
+}
+
+// recordStream is a server stream receiving the given records
+type recordStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	records []*pb.ImportAccountRecord
+}
+
+func (s *recordStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *recordStream) RecvMsg(m any) error {
+	if len(s.records) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.records[0])
+	s.records = s.records[1:]
+	return nil
+}
+
+func tlsState(cert *x509.Certificate) tls.ConnectionState {
+	return tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+}
+
+func mustParse(t *testing.T, id string) tb_types.Uint128 {
+	t.Helper()
+	parsed, err := tbutil.ParseID(id)
+	require.NoError(t, err)
+	return parsed
+}
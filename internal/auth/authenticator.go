@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ErrNoCredentials is returned by an Authenticator when the call carries no
+// credentials of its kind, so that the next one may be tried.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator identifies the caller of an RPC.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (*Identity, error)
+}
+
+// CertAuthenticator identifies callers by the verified client certificate of
+// an mTLS connection.
+type CertAuthenticator struct{}
+
+// Authenticate returns the common name of the client certificate, or its
+// first DNS or URI SAN when the name is empty.
+func (CertAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	cert := info.State.VerifiedChains[0][0]
+	subject := cert.Subject.CommonName
+	switch {
+	case subject != "":
+	case len(cert.DNSNames) > 0:
+		subject = cert.DNSNames[0]
+	case len(cert.URIs) > 0:
+		subject = cert.URIs[0].String()
+	default:
+		return nil, errors.New("client certificate has no subject")
+	}
+	return &Identity{Subject: subject, Method: "mtls"}, nil
+}
+
+// signatureAlgorithms are the JWT algorithms accepted
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// JWTAuthenticator identifies callers by a bearer token in the authorization
+// metadata, signed by a key of a JWKS.
+type JWTAuthenticator struct {
+	keys     jose.JSONWebKeySet
+	issuer   string
+	audience string
+}
+
+// NewJWTAuthenticator loads the JWKS at path. Tokens must carry a sub claim,
+// be within their validity period and, when set, match issuer and audience.
+func NewJWTAuthenticator(path, issuer, audience string) (*JWTAuthenticator, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS %s: %w", path, err)
+	}
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("JWKS %s has no keys", path)
+	}
+
+	return &JWTAuthenticator{keys: keys, issuer: issuer, audience: audience}, nil
+}
+
+// Authenticate validates the bearer token of the call.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, ErrNoCredentials
+	}
+	raw, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	token, err := jwt.ParseSigned(raw, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("malformed token: %w", err)
+	}
+	if len(token.Headers) == 0 {
+		return nil, errors.New("token has no header")
+	}
+
+	candidates := a.keys.Keys
+	if kid := token.Headers[0].KeyID; kid != "" {
+		candidates = a.keys.Key(kid)
+	}
+
+	var claims jwt.Claims
+	verified := false
+	for _, key := range candidates {
+		if err := token.Claims(key.Public().Key, &claims); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("token signature does not match any known key")
+	}
+
+	expected := jwt.Expected{Issuer: a.issuer}
+	if a.audience != "" {
+		expected.AnyAudience = jwt.Audience{a.audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, time.Minute); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if claims.Expiry == nil {
+		return nil, errors.New("token has no expiry")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	return &Identity{Subject: claims.Subject, Method: "jwt"}, nil
+}
@@ -0,0 +1,27 @@
+// Package auth authenticates callers with mTLS client certificates or bearer
+// JWTs and authorizes their RPCs against a policy restricting the methods,
+// ledgers and account codes each identity may use.
+package auth
+
+import "context"
+
+// Identity is an authenticated caller.
+type Identity struct {
+	// Subject is the certificate common name or the JWT sub claim.
+	Subject string
+	// Method is how the caller authenticated: "mtls" or "jwt".
+	Method string
+}
+
+type identityKey struct{}
+
+// NewContext returns a context carrying id.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the identity of the caller, if authenticated.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// publicPrefixes are the methods served without authentication, so that
+// orchestrators can probe health and tools can list the schema
+var publicPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// Interceptor authenticates every RPC with the first authenticator that
+// finds credentials and authorizes it against the policy.
+type Interceptor struct {
+	policy         *Policy
+	lookup         Lookup
+	authenticators []Authenticator
+}
+
+// NewInterceptor builds an interceptor. lookup resolves the accounts and
+// transfers of a request to check their ledgers and codes.
+func NewInterceptor(policy *Policy, lookup Lookup, authenticators ...Authenticator) *Interceptor {
+	return &Interceptor{policy: policy, lookup: lookup, authenticators: authenticators}
+}
+
+// UnaryInterceptor authorizes unary RPCs, including the ledgers and account
+// codes of their request.
+func (i *Interceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, rule, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if err := scopeOf(req).check(ctx, rule, i.lookup); err != nil {
+			return nil, denied(ctx, info.FullMethod, err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authorizes streaming RPCs, including the ledgers and
+// account codes of every message the client sends.
+func (i *Interceptor) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, rule, err := i.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx, method: info.FullMethod, rule: rule, lookup: i.lookup})
+	}
+}
+
+// identityStream carries the caller's identity in its context and checks
+// each received message against the caller's rule
+type identityStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	method string
+	rule   *Rule
+	lookup Lookup
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *identityStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := scopeOf(m).check(s.ctx, s.rule, s.lookup); err != nil {
+		return denied(s.ctx, s.method, err)
+	}
+	return nil
+}
+
+// authorize authenticates the caller and returns its rule if it grants the
+// method
+func (i *Interceptor) authorize(ctx context.Context, method string) (context.Context, *Rule, error) {
+	id, err := i.authenticate(ctx)
+	if err != nil {
+		logger.InfoContext(ctx, "authentication failed", "error", err)
+		return ctx, nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = NewContext(ctx, id)
+	ctx = logger.WithFields(ctx, "subject", id.Subject, "auth", id.Method)
+
+	rule, ok := i.policy.Rule(id.Subject)
+	if !ok || !rule.AllowsMethod(method) {
+		return ctx, nil, denied(ctx, method, errDenied)
+	}
+	return ctx, rule, nil
+}
+
+func (i *Interceptor) authenticate(ctx context.Context) (*Identity, error) {
+	for _, a := range i.authenticators {
+		id, err := a.Authenticate(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return id, err
+	}
+	return nil, errors.New("missing credentials")
+}
+
+func denied(ctx context.Context, method string, err error) error {
+	if !errors.Is(err, errDenied) {
+		logger.ErrorContext(ctx, "authorization lookup failed", "error", err)
+		return status.Error(codes.Unavailable, "authorization lookup failed")
+	}
+	id, _ := FromContext(ctx)
+	logger.InfoContext(ctx, "permission denied", "reason", err)
+	return status.Errorf(codes.PermissionDenied, "%s may not call %s: %v", id.Subject, method, err)
+}
+
+func isPublic(method string) bool {
+	for _, prefix := range publicPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
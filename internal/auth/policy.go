@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy lists what each identity may do. Identities without a rule are
+// denied everything.
+//
+//	rules:
+//	  - subject: payouts
+//	    methods: [CreateTransfer, GetAccount]
+//	    ledgers: [2]
+//	    account_codes: [100]
+//	  - subject: admin
+//	    methods: ["*"]
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule grants an identity access to some RPCs. Empty ledgers or account
+// codes allow any. A restricted rule only allows queries filtered by one of
+// its ledgers and account codes; transfer queries, which cannot be narrowed
+// to account codes, need a rule without them.
+type Rule struct {
+	// Subject is the certificate common name or JWT subject.
+	Subject string `yaml:"subject"`
+	// Methods are RPC names, e.g. CreateTransfer, or "*" for all.
+	Methods []string `yaml:"methods"`
+	// Ledgers restricts the ledgers of the transfers and accounts involved.
+	Ledgers []uint32 `yaml:"ledgers"`
+	// AccountCodes restricts the codes of the accounts created, debited or
+	// read.
+	AccountCodes []uint16 `yaml:"account_codes"`
+}
+
+// LoadPolicy reads a YAML policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open policy: %w", err)
+	}
+	defer f.Close()
+
+	var policy Policy
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+// Validate rejects rules without a subject or methods, and subjects with
+// more than one rule.
+func (p *Policy) Validate() error {
+	var errs []error
+	seen := make(map[string]bool)
+	for i, rule := range p.Rules {
+		if rule.Subject == "" {
+			errs = append(errs, fmt.Errorf("rules[%d]: subject is required", i))
+		}
+		if len(rule.Methods) == 0 {
+			errs = append(errs, fmt.Errorf("rules[%d]: at least one method is required", i))
+		}
+		if seen[rule.Subject] {
+			errs = append(errs, fmt.Errorf("rules[%d]: duplicate subject %q", i, rule.Subject))
+		}
+		seen[rule.Subject] = true
+	}
+	return errors.Join(errs...)
+}
+
+// Rule returns the rule of a subject.
+func (p *Policy) Rule(subject string) (*Rule, bool) {
+	for i := range p.Rules {
+		if p.Rules[i].Subject == subject {
+			return &p.Rules[i], true
+		}
+	}
+	return nil, false
+}
+
+// AllowsMethod reports whether the rule grants a full method name such as
+// /financial.FinancialService/CreateTransfer.
+func (r *Rule) AllowsMethod(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return slices.Contains(r.Methods, "*") || slices.Contains(r.Methods, name)
+}
+
+// AllowsLedger reports whether the rule grants a ledger.
+func (r *Rule) AllowsLedger(ledger uint32) bool {
+	return len(r.Ledgers) == 0 || slices.Contains(r.Ledgers, ledger)
+}
+
+// AllowsAccountCode reports whether the rule grants an account code.
+func (r *Rule) AllowsAccountCode(code uint16) bool {
+	return len(r.AccountCodes) == 0 || slices.Contains(r.AccountCodes, code)
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Lookup resolves the accounts and transfers a request refers to by ID.
+type Lookup interface {
+	GetAccount(ctx context.Context, id tb_types.Uint128) (*tb_types.Account, error)
+	GetTransfer(ctx context.Context, id tb_types.Uint128) (*tb_types.Transfer, error)
+}
+
+// scope is what a request touches, as far as the policy is concerned
+type scope struct {
+	ledgers []uint32
+	// codes of the accounts being created
+	accountCodes []uint16
+	// accounts debited or read
+	accounts []tb_types.Uint128
+	// transfers resolved or read; their ledger and debit account are checked
+	transfers []tb_types.Uint128
+	// set by queries that leave the ledger or account code unfiltered and so
+	// may return objects of any
+	anyLedger, anyAccountCode bool
+	// set for request types scopeOf does not know
+	unlisted bool
+}
+
+func (s *scope) ledger(ledger uint32) {
+	if ledger != 0 {
+		s.ledgers = append(s.ledgers, ledger)
+	}
+}
+
+func (s *scope) accountCode(code uint32) {
+	if code <= math.MaxUint16 {
+		s.accountCodes = append(s.accountCodes, uint16(code))
+	}
+}
+
+// queryLedger adds the ledger a query is filtered by, if any
+func (s *scope) queryLedger(ledger uint32) {
+	if ledger == 0 {
+		s.anyLedger = true
+	}
+	s.ledger(ledger)
+}
+
+// account and transfer skip malformed IDs, which the service rejects anyway
+func (s *scope) account(id string) {
+	if parsed, err := tbutil.ParseID(id); err == nil && parsed != (tb_types.Uint128{}) {
+		s.accounts = append(s.accounts, parsed)
+	}
+}
+
+func (s *scope) transfer(id string) {
+	if parsed, err := tbutil.ParseID(id); err == nil && parsed != (tb_types.Uint128{}) {
+		s.transfers = append(s.transfers, parsed)
+	}
+}
+
+// scopeOf lists the ledgers, accounts and transfers of a request
+func scopeOf(req any) scope {
+	var s scope
+	switch req := req.(type) {
+	case *pb.CreateAccountRequest:
+		s.ledger(req.Ledger)
+		s.accountCode(req.Code)
+	case *pb.CreateAccountsBatchRequest:
+		for _, account := range req.Accounts {
+			s.ledger(account.Ledger)
+			s.accountCode(account.Code)
+		}
+	case *pb.GetAccountRequest:
+		s.account(req.Id)
+	case *pb.CreateTransferRequest:
+		s.addTransfer(req)
+	case *pb.CreateTransfersBatchRequest:
+		for _, transfer := range req.Transfers {
+			s.addTransfer(transfer)
+		}
+	case *pb.GetTransferRequest:
+		s.transfer(req.Id)
+	case *pb.ReserveFundsRequest:
+		s.ledger(req.Ledger)
+		s.account(req.DebitAccountId)
+	case *pb.CapturePendingRequest:
+		s.transfer(req.PendingId)
+	case *pb.VoidPendingRequest:
+		s.transfer(req.PendingId)
+	case *pb.CreateLinkedTransfersRequest:
+		for _, leg := range req.Legs {
+			s.ledger(leg.Ledger)
+			s.account(leg.DebitAccountId)
+		}
+	case *pb.ListAccountTransfersRequest:
+		s.account(req.AccountId)
+	case *pb.GetAccountBalancesRequest:
+		s.account(req.AccountId)
+	case *pb.GetBalanceAtRequest:
+		s.account(req.AccountId)
+	case *pb.QueryAccountsRequest:
+		s.queryLedger(req.Ledger)
+		if req.Code == 0 {
+			s.anyAccountCode = true
+		} else {
+			s.accountCode(req.Code)
+		}
+	case *pb.QueryTransfersRequest:
+		// The transfer code says nothing about the accounts involved
+		s.queryLedger(req.Ledger)
+		s.anyAccountCode = true
+	case *pb.ImportAccountRecord:
+		if req.Account != nil {
+			s.ledger(req.Account.Ledger)
+			s.accountCode(req.Account.Code)
+		}
+	case *pb.ImportTransferRecord:
+		if req.Transfer != nil {
+			s.addTransfer(req.Transfer)
+		}
+	case *pb.GetImportCheckpointRequest:
+		// The checkpoint is the newest object of any ledger
+		s.anyLedger = true
+	default:
+		// A request type missing above may touch any ledger or account, so
+		// that rules restricted to some are denied rather than bypassed
+		s.unlisted = true
+		s.anyLedger = true
+		s.anyAccountCode = true
+	}
+	return s
+}
+
+func (s *scope) addTransfer(req *pb.CreateTransferRequest) {
+	s.ledger(req.Ledger)
+	s.account(req.DebitAccountId)
+	if req.PendingId != "" {
+		s.transfer(req.PendingId)
+	}
+}
+
+// errDenied reports a scope outside of the caller's rule
+var errDenied = errors.New("denied")
+
+// check verifies the scope against a rule, looking up the accounts and
+// transfers it refers to. Unknown IDs are let through: the request fails
+// on them with NotFound.
+func (s scope) check(ctx context.Context, rule *Rule, lookup Lookup) error {
+	for _, ledger := range s.ledgers {
+		if !rule.AllowsLedger(ledger) {
+			return fmt.Errorf("%w: ledger %d", errDenied, ledger)
+		}
+	}
+	for _, code := range s.accountCodes {
+		if !rule.AllowsAccountCode(code) {
+			return fmt.Errorf("%w: account code %d", errDenied, code)
+		}
+	}
+	if s.unlisted && (len(rule.Ledgers) > 0 || len(rule.AccountCodes) > 0) {
+		return fmt.Errorf("%w: request without a scope", errDenied)
+	}
+	if s.anyLedger && len(rule.Ledgers) > 0 {
+		return fmt.Errorf("%w: query across ledgers", errDenied)
+	}
+	if s.anyAccountCode && len(rule.AccountCodes) > 0 {
+		return fmt.Errorf("%w: query across account codes", errDenied)
+	}
+	if len(rule.Ledgers) == 0 && len(rule.AccountCodes) == 0 {
+		return nil
+	}
+
+	accounts := s.accounts
+	for _, id := range s.transfers {
+		transfer, err := lookup.GetTransfer(ctx, id)
+		if errors.Is(err, repository.ErrTransferNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !rule.AllowsLedger(transfer.Ledger) {
+			return fmt.Errorf("%w: ledger %d", errDenied, transfer.Ledger)
+		}
+		accounts = append(accounts, transfer.DebitAccountID)
+	}
+
+	for _, id := range accounts {
+		account, err := lookup.GetAccount(ctx, id)
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !rule.AllowsLedger(account.Ledger) {
+			return fmt.Errorf("%w: ledger %d", errDenied, account.Ledger)
+		}
+		if !rule.AllowsAccountCode(account.Code) {
+			return fmt.Errorf("%w: account code %d", errDenied, account.Code)
+		}
+	}
+	return nil
+}
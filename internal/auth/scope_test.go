@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"testing"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Every RPC must be scoped, or restricted rules deny it whatever it touches
+func TestEveryRequestHasScope(t *testing.T) {
+	desc := pb.FinancialService_ServiceDesc
+	found, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(desc.ServiceName))
+	require.NoError(t, err)
+	service := found.(protoreflect.ServiceDescriptor)
+
+	var methods []string
+	for _, m := range desc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	for _, s := range desc.Streams {
+		methods = append(methods, s.StreamName)
+	}
+	require.NotEmpty(t, methods)
+
+	for _, name := range methods {
+		method := service.Methods().ByName(protoreflect.Name(name))
+		require.NotNil(t, method, name)
+		input, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+		require.NoError(t, err)
+
+		req := input.New().Interface()
+		assert.False(t, scopeOf(req).unlisted, "%s: %T is not listed in scopeOf", name, req)
+	}
+}
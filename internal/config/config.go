@@ -0,0 +1,463 @@
+// Package config loads the server configuration from defaults, an optional
+// YAML file, environment variables and command-line flags, in increasing
+// order of precedence.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the environment variable of every flag: -tb-cluster-id
+// is read from TB_SERVICE_TB_CLUSTER_ID.
+const EnvPrefix = "TB_SERVICE_"
+
+// Config is the effective server configuration.
+type Config struct {
+	// Listen is the gRPC listen address, e.g. ":50051" or "127.0.0.1:50051".
+	Listen string `yaml:"listen"`
+	// Memory serves from an in-memory ledger instead of a TigerBeetle cluster.
+	Memory bool `yaml:"memory"`
+
+	TigerBeetle TigerBeetle `yaml:"tigerbeetle"`
+	Log         Log         `yaml:"log"`
+	TLS         TLS         `yaml:"tls"`
+	Auth        Auth        `yaml:"auth"`
+	Health      Health      `yaml:"health"`
+	Metrics     Metrics     `yaml:"metrics"`
+	Tracing     Tracing     `yaml:"tracing"`
+	Gateway     Gateway     `yaml:"gateway"`
+
+	// RequestTimeout bounds every RPC that arrives without a shorter deadline
+	// (0 disables it).
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// ShutdownTimeout bounds how long in-flight RPCs may take to drain on
+	// SIGTERM before they are cut off (0 cuts them off immediately).
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	// PrintConfig asks the caller to dump the effective configuration and exit.
+	PrintConfig bool `yaml:"-"`
+}
+
+// TigerBeetle holds the cluster connection settings.
+type TigerBeetle struct {
+	// Addresses of every replica, as "port" or "host:port".
+	Addresses []string `yaml:"addresses"`
+	ClusterID uint64   `yaml:"cluster_id"`
+	// BatchWindow, when set, coalesces concurrent CreateTransfer calls
+	// arriving within it into a single request of at most BatchSize
+	// transfers.
+	BatchWindow time.Duration `yaml:"batch_window"`
+	BatchSize   int           `yaml:"batch_size"`
+}
+
+// Log holds the logger settings.
+type Log struct {
+	// Level is one of debug, info, warn or error.
+	Level string `yaml:"level"`
+	// Format is either console or json.
+	Format string `yaml:"format"`
+	// AdminListen is the HTTP address serving /loglevel, which reports the
+	// level on GET and changes it on PUT. The endpoint is unauthenticated,
+	// so it should be bound to a loopback or private address; empty
+	// disables it.
+	AdminListen string `yaml:"admin_listen"`
+}
+
+// Health holds the settings of the background ledger health check.
+type Health struct {
+	// Interval between two pings of the cluster.
+	Interval time.Duration `yaml:"interval"`
+	// Timeout after which an unanswered ping marks the server NOT_SERVING.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Metrics holds the settings of the Prometheus endpoint.
+type Metrics struct {
+	// Listen is the HTTP address serving /metrics; empty disables it. It
+	// serves nothing else, so that it can be exposed to the scraper: the
+	// log level endpoint has its own address, Log.AdminListen.
+	Listen string `yaml:"listen"`
+}
+
+// Gateway holds the settings of the HTTP/JSON gateway.
+type Gateway struct {
+	// Listen is the HTTP address of the gateway, served with the same TLS
+	// settings as gRPC; empty disables it.
+	Listen string `yaml:"listen"`
+}
+
+// Tracing holds the OpenTelemetry settings.
+type Tracing struct {
+	// Exporter is one of none, stdout or otlp.
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the OTLP/gRPC collector address; empty falls back to
+	// OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure sends spans to the collector without TLS.
+	Insecure bool `yaml:"insecure"`
+	// SampleRatio is the fraction of new traces recorded; traces started by
+	// the caller follow its sampling decision.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// TLS holds the server certificate settings. TLS is enabled when a
+// certificate is configured; a client CA additionally requires clients to
+// present a certificate signed by it. The files are reloaded when they
+// change.
+type TLS struct {
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"`
+	// MinVersion is the oldest TLS version accepted, 1.2 or 1.3.
+	MinVersion string `yaml:"min_version"`
+}
+
+// Auth holds the authentication and authorization settings. Callers are
+// authenticated by their mTLS client certificate, when tls.client_ca_file is
+// set, or by a bearer JWT, when jwks_file is set.
+type Auth struct {
+	// PolicyFile lists what each identity may call; setting it enables
+	// authentication on every FinancialService RPC.
+	PolicyFile string `yaml:"policy_file"`
+	// JWKSFile holds the keys that sign accepted JWTs.
+	JWKSFile string `yaml:"jwks_file"`
+	// JWTIssuer and JWTAudience, when set, must match the token claims.
+	JWTIssuer   string `yaml:"jwt_issuer"`
+	JWTAudience string `yaml:"jwt_audience"`
+}
+
+// Enabled reports whether RPCs must be authenticated.
+func (a Auth) Enabled() bool {
+	return a.PolicyFile != ""
+}
+
+// Enabled reports whether the server should serve TLS.
+func (t TLS) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// Default returns the configuration used when nothing else is set. The
+// TigerBeetle address matches the replica started by docker-compose.yml.
+func Default() *Config {
+	return &Config{
+		Listen: ":50051",
+		TigerBeetle: TigerBeetle{
+			Addresses: []string{"3001"},
+			BatchSize: 512,
+		},
+		Log: Log{
+			Level:  "info",
+			Format: "console",
+		},
+		TLS: TLS{
+			MinVersion: "1.2",
+		},
+		Health: Health{
+			Interval: 5 * time.Second,
+			Timeout:  2 * time.Second,
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			SampleRatio: 1,
+		},
+		RequestTimeout:  30 * time.Second,
+		ShutdownTimeout: 15 * time.Second,
+	}
+}
+
+// Load builds the configuration for a program called name from its
+// arguments and environment. The config file is taken from -config or
+// TB_SERVICE_CONFIG. The result is validated; flag.ErrHelp is returned when
+// -h was given.
+func Load(name string, args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	// A first pass only finds the config file; flags are applied again
+	// below so that they override it.
+	var path string
+	probe := newFlagSet(name, Default(), &path)
+	probe.SetOutput(io.Discard)
+	if err := probe.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			probe.SetOutput(os.Stderr)
+			probe.Usage()
+		}
+		return nil, err
+	}
+	if path == "" {
+		path, _ = lookupEnv(EnvPrefix + "CONFIG")
+	}
+
+	cfg := Default()
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	fs := newFlagSet(name, cfg, new(string))
+	fs.SetOutput(io.Discard)
+	var envErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		if value, ok := lookupEnv(envName(f.Name)); ok {
+			if err := fs.Set(f.Name, value); err != nil {
+				envErr = errors.Join(envErr, fmt.Errorf("%s: %w", envName(f.Name), err))
+			}
+		}
+	})
+	if envErr != nil {
+		return nil, envErr
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func newFlagSet(name string, cfg *Config, path *string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	fs.StringVar(path, "config", "", "Path to a YAML config file")
+	fs.BoolVar(&cfg.PrintConfig, "print-config", false, "Print the effective configuration and exit")
+
+	fs.StringVar(&cfg.Listen, "listen", cfg.Listen, "gRPC listen address")
+	fs.Var(portValue{&cfg.Listen}, "port", "gRPC port on all interfaces (deprecated, use -listen)")
+	fs.BoolVar(&cfg.Memory, "memory", cfg.Memory, "Serve from an in-memory ledger instead of TigerBeetle (local development)")
+
+	fs.Var((*listValue)(&cfg.TigerBeetle.Addresses), "tb-addresses", "Comma-separated TigerBeetle replica addresses")
+	fs.Uint64Var(&cfg.TigerBeetle.ClusterID, "tb-cluster-id", cfg.TigerBeetle.ClusterID, "TigerBeetle cluster ID")
+	fs.DurationVar(&cfg.TigerBeetle.BatchWindow, "tb-batch-window", cfg.TigerBeetle.BatchWindow, "Time CreateTransfer calls wait to share a request with concurrent ones (0 disables batching)")
+	fs.IntVar(&cfg.TigerBeetle.BatchSize, "tb-batch-size", cfg.TigerBeetle.BatchSize, "Largest number of CreateTransfer calls sent in one request")
+
+	fs.StringVar(&cfg.Log.Level, "log-level", cfg.Log.Level, "Log level: debug, info, warn or error")
+	fs.StringVar(&cfg.Log.Format, "log-format", cfg.Log.Format, "Log format: console or json")
+	fs.StringVar(&cfg.Log.AdminListen, "log-admin-listen", cfg.Log.AdminListen, "Unauthenticated HTTP address to read and change the log level on /loglevel (empty disables it)")
+
+	fs.StringVar(&cfg.TLS.CertFile, "tls-cert", cfg.TLS.CertFile, "Server certificate file (enables TLS)")
+	fs.StringVar(&cfg.TLS.KeyFile, "tls-key", cfg.TLS.KeyFile, "Server private key file")
+	fs.StringVar(&cfg.TLS.ClientCAFile, "tls-client-ca", cfg.TLS.ClientCAFile, "CA bundle for client certificates (enables mTLS)")
+	fs.StringVar(&cfg.TLS.MinVersion, "tls-min-version", cfg.TLS.MinVersion, "Minimum TLS version: 1.2 or 1.3")
+
+	fs.StringVar(&cfg.Auth.PolicyFile, "auth-policy", cfg.Auth.PolicyFile, "Authorization policy file (enables authentication)")
+	fs.StringVar(&cfg.Auth.JWKSFile, "auth-jwks", cfg.Auth.JWKSFile, "JWKS file with the keys of accepted bearer tokens")
+	fs.StringVar(&cfg.Auth.JWTIssuer, "auth-jwt-issuer", cfg.Auth.JWTIssuer, "Required iss claim of bearer tokens")
+	fs.StringVar(&cfg.Auth.JWTAudience, "auth-jwt-audience", cfg.Auth.JWTAudience, "Required aud claim of bearer tokens")
+
+	fs.DurationVar(&cfg.Health.Interval, "health-interval", cfg.Health.Interval, "Interval between ledger health checks")
+	fs.DurationVar(&cfg.Health.Timeout, "health-timeout", cfg.Health.Timeout, "Time a ledger health check may take before the server is reported NOT_SERVING")
+
+	fs.StringVar(&cfg.Metrics.Listen, "metrics-listen", cfg.Metrics.Listen, "HTTP address serving Prometheus metrics on /metrics (empty disables it)")
+
+	fs.StringVar(&cfg.Gateway.Listen, "gateway-listen", cfg.Gateway.Listen, "HTTP/JSON gateway address (empty disables it)")
+
+	fs.StringVar(&cfg.Tracing.Exporter, "trace-exporter", cfg.Tracing.Exporter, "Trace exporter: none, stdout or otlp")
+	fs.StringVar(&cfg.Tracing.Endpoint, "trace-endpoint", cfg.Tracing.Endpoint, "OTLP/gRPC collector address")
+	fs.BoolVar(&cfg.Tracing.Insecure, "trace-insecure", cfg.Tracing.Insecure, "Send spans to the collector without TLS")
+	fs.Float64Var(&cfg.Tracing.SampleRatio, "trace-sample-ratio", cfg.Tracing.SampleRatio, "Fraction of new traces to record, from 0 to 1")
+
+	fs.DurationVar(&cfg.RequestTimeout, "request-timeout", cfg.RequestTimeout, "Deadline applied to RPCs without a shorter one (0 disables it)")
+	fs.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", cfg.ShutdownTimeout, "Time allowed for in-flight RPCs to finish on shutdown")
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s:\n", name)
+		fs.PrintDefaults()
+		fmt.Fprintf(fs.Output(), "\nEvery flag can also be set through %s<FLAG>, e.g. %s\n", EnvPrefix, envName("tb-addresses"))
+	}
+	return fs
+}
+
+// envName returns the environment variable for a flag
+func envName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.Listen); err != nil {
+		errs = append(errs, fmt.Errorf("listen: %w", err))
+	}
+
+	if !c.Memory {
+		if len(c.TigerBeetle.Addresses) == 0 {
+			errs = append(errs, errors.New("tigerbeetle.addresses: at least one replica address is required"))
+		}
+		for _, address := range c.TigerBeetle.Addresses {
+			if err := validateReplicaAddress(address); err != nil {
+				errs = append(errs, fmt.Errorf("tigerbeetle.addresses: %q: %w", address, err))
+			}
+		}
+	}
+
+	if c.TigerBeetle.BatchWindow < 0 {
+		errs = append(errs, errors.New("tigerbeetle.batch_window: must not be negative"))
+	}
+	// 8189 is the most events the client sends in one request
+	if c.TigerBeetle.BatchSize < 1 || c.TigerBeetle.BatchSize > 8189 {
+		errs = append(errs, errors.New("tigerbeetle.batch_size: must be between 1 and 8189"))
+	}
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level: unknown level %q", c.Log.Level))
+	}
+	switch c.Log.Format {
+	case "console", "json":
+	default:
+		errs = append(errs, fmt.Errorf("log.format: unknown format %q", c.Log.Format))
+	}
+	if c.Log.AdminListen != "" {
+		if _, _, err := net.SplitHostPort(c.Log.AdminListen); err != nil {
+			errs = append(errs, fmt.Errorf("log.admin_listen: %w", err))
+		}
+	}
+
+	if c.TLS.Enabled() && c.TLS.KeyFile == "" {
+		errs = append(errs, errors.New("tls.key_file: required when tls.cert_file is set"))
+	}
+	if !c.TLS.Enabled() && (c.TLS.KeyFile != "" || c.TLS.ClientCAFile != "") {
+		errs = append(errs, errors.New("tls.cert_file: required when tls.key_file or tls.client_ca_file is set"))
+	}
+	switch c.TLS.MinVersion {
+	case "1.2", "1.3":
+	default:
+		errs = append(errs, fmt.Errorf("tls.min_version: unsupported version %q", c.TLS.MinVersion))
+	}
+
+	if c.Auth.Enabled() && c.Auth.JWKSFile == "" && c.TLS.ClientCAFile == "" {
+		errs = append(errs, errors.New("auth.policy_file: requires auth.jwks_file or tls.client_ca_file to authenticate callers"))
+	}
+	if !c.Auth.Enabled() && (c.Auth.JWKSFile != "" || c.Auth.JWTIssuer != "" || c.Auth.JWTAudience != "") {
+		errs = append(errs, errors.New("auth.policy_file: required when JWT authentication is configured"))
+	}
+
+	if c.Health.Interval <= 0 {
+		errs = append(errs, errors.New("health.interval: must be positive"))
+	}
+	if c.Health.Timeout <= 0 {
+		errs = append(errs, errors.New("health.timeout: must be positive"))
+	}
+
+	if c.Metrics.Listen != "" {
+		if _, _, err := net.SplitHostPort(c.Metrics.Listen); err != nil {
+			errs = append(errs, fmt.Errorf("metrics.listen: %w", err))
+		}
+	}
+
+	if c.Gateway.Listen != "" {
+		if _, _, err := net.SplitHostPort(c.Gateway.Listen); err != nil {
+			errs = append(errs, fmt.Errorf("gateway.listen: %w", err))
+		}
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: unknown exporter %q", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sample_ratio: must be between 0 and 1"))
+	}
+
+	if c.RequestTimeout < 0 {
+		errs = append(errs, errors.New("request_timeout: must not be negative"))
+	}
+	if c.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("shutdown_timeout: must not be negative"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateReplicaAddress accepts the forms understood by the TigerBeetle
+// client: a port, or a host and port
+func validateReplicaAddress(address string) error {
+	port := address
+	if strings.Contains(address, ":") {
+		var err error
+		if _, port, err = net.SplitHostPort(address); err != nil {
+			return err
+		}
+	}
+	if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
+}
+
+// Write dumps the configuration as YAML, in the format accepted by -config.
+func (c *Config) Write(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// listValue is a comma-separated flag that replaces the whole list
+type listValue []string
+
+func (l *listValue) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *listValue) Set(s string) error {
+	*l = nil
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// portValue sets the listen address to all interfaces on a port
+type portValue struct {
+	listen *string
+}
+
+func (p portValue) String() string {
+	return ""
+}
+
+func (p portValue) Set(s string) error {
+	port, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return fmt.Errorf("invalid port %q", s)
+	}
+	*p.listen = ":" + strconv.FormatUint(port, 10)
+	return nil
+}
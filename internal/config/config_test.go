@@ -0,0 +1,149 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := config.Load("server", nil, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, config.Default(), cfg)
+	assert.Equal(t, []string{"3001"}, cfg.TigerBeetle.Addresses)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, `
+listen: ":6000"
+tigerbeetle:
+  addresses: ["10.0.0.1:3000", "10.0.0.2:3000"]
+  cluster_id: 7
+log:
+  level: debug
+request_timeout: 5s
+`)
+
+	t.Run("file over defaults", func(t *testing.T) {
+		cfg, err := config.Load("server", []string{"-config", path}, env(nil))
+		require.NoError(t, err)
+		assert.Equal(t, ":6000", cfg.Listen)
+		assert.Equal(t, []string{"10.0.0.1:3000", "10.0.0.2:3000"}, cfg.TigerBeetle.Addresses)
+		assert.Equal(t, uint64(7), cfg.TigerBeetle.ClusterID)
+		assert.Equal(t, "debug", cfg.Log.Level)
+		assert.Equal(t, "console", cfg.Log.Format)
+		assert.Equal(t, 5*time.Second, cfg.RequestTimeout)
+	})
+
+	t.Run("environment over file", func(t *testing.T) {
+		cfg, err := config.Load("server", nil, env(map[string]string{
+			"TB_SERVICE_CONFIG":        path,
+			"TB_SERVICE_TB_CLUSTER_ID": "9",
+			"TB_SERVICE_LOG_FORMAT":    "json",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, ":6000", cfg.Listen)
+		assert.Equal(t, uint64(9), cfg.TigerBeetle.ClusterID)
+		assert.Equal(t, "json", cfg.Log.Format)
+	})
+
+	t.Run("flags over environment", func(t *testing.T) {
+		cfg, err := config.Load("server",
+			[]string{"-config", path, "-tb-cluster-id", "11", "-tb-addresses", "3005, 3006"},
+			env(map[string]string{"TB_SERVICE_TB_CLUSTER_ID": "9"}))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), cfg.TigerBeetle.ClusterID)
+		assert.Equal(t, []string{"3005", "3006"}, cfg.TigerBeetle.Addresses)
+	})
+
+	t.Run("port alias", func(t *testing.T) {
+		cfg, err := config.Load("server", []string{"-port", "7000"}, env(nil))
+		require.NoError(t, err)
+		assert.Equal(t, ":7000", cfg.Listen)
+	})
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Run("invalid settings are reported together", func(t *testing.T) {
+		_, err := config.Load("server", []string{
+			"-listen", "nope",
+			"-tb-addresses", "host:port",
+			"-log-level", "loud",
+			"-log-admin-listen", "6060",
+			"-tls-key", "server.key",
+			"-metrics-listen", "9090",
+			"-trace-exporter", "jaeger",
+			"-auth-jwks", "keys.json",
+			"-tls-min-version", "1.1",
+			"-tb-batch-size", "10000",
+			"-gateway-listen", "8080",
+		}, env(nil))
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "listen")
+		assert.ErrorContains(t, err, "tigerbeetle.addresses")
+		assert.ErrorContains(t, err, "log.level")
+		assert.ErrorContains(t, err, "log.admin_listen")
+		assert.ErrorContains(t, err, "tls.cert_file")
+		assert.ErrorContains(t, err, "metrics.listen")
+		assert.ErrorContains(t, err, "tracing.exporter")
+		assert.ErrorContains(t, err, "auth.policy_file")
+		assert.ErrorContains(t, err, "tls.min_version")
+		assert.ErrorContains(t, err, "tigerbeetle.batch_size")
+		assert.ErrorContains(t, err, "gateway.listen")
+	})
+
+	t.Run("memory ledger needs no replicas", func(t *testing.T) {
+		_, err := config.Load("server", []string{"-memory", "-tb-addresses", ""}, env(nil))
+		assert.NoError(t, err)
+	})
+
+	t.Run("policy without authenticator", func(t *testing.T) {
+		_, err := config.Load("server", []string{"-auth-policy", "policy.yaml"}, env(nil))
+		assert.ErrorContains(t, err, "auth.policy_file")
+	})
+
+	t.Run("unknown file key", func(t *testing.T) {
+		path := writeFile(t, "tigerbeetle:\n  adresses: [\"3000\"]\n")
+		_, err := config.Load("server", []string{"-config", path}, env(nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed environment value", func(t *testing.T) {
+		_, err := config.Load("server", nil, env(map[string]string{"TB_SERVICE_TB_CLUSTER_ID": "x"}))
+		assert.ErrorContains(t, err, "TB_SERVICE_TB_CLUSTER_ID")
+	})
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	cfg, err := config.Load("server", []string{"-tb-addresses", "3001,3002", "-log-format", "json", "-print-config"}, env(nil))
+	require.NoError(t, err)
+	assert.True(t, cfg.PrintConfig)
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.Write(&buf))
+
+	reloaded, err := config.Load("server", []string{"-config", writeFile(t, buf.String())}, env(nil))
+	require.NoError(t, err)
+	cfg.PrintConfig = false
+	assert.Equal(t, cfg, reloaded)
+}
@@ -0,0 +1,267 @@
+// Package gateway serves part of FinancialService as HTTP/JSON for tools
+// that cannot speak gRPC. Requests run through the same unary interceptors
+// as gRPC calls, so they are logged, measured, authenticated and authorized
+// alike, and errors are JSON renderings of the gRPC status.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/middleware"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxBodySize bounds request bodies
+const maxBodySize = 1 << 20
+
+var tracer = otel.Tracer("github.com/pauloaugusto-dmf/tigerbeetle-service/internal/gateway")
+
+var (
+	marshaler   = protojson.MarshalOptions{EmitUnpopulated: true}
+	unmarshaler = protojson.UnmarshalOptions{}
+)
+
+// forwardedHeaders are passed to the interceptors as incoming metadata
+var forwardedHeaders = []string{"authorization", middleware.RequestIDKey}
+
+// Gateway translates HTTP requests into FinancialService calls.
+type Gateway struct {
+	service     pb.FinancialServiceServer
+	interceptor grpc.UnaryServerInterceptor
+}
+
+// New returns a gateway calling service through interceptors, applied in
+// order as with grpc.ChainUnaryInterceptor.
+func New(service pb.FinancialServiceServer, interceptors ...grpc.UnaryServerInterceptor) *Gateway {
+	return &Gateway{service: service, interceptor: chain(interceptors)}
+}
+
+// Handler routes:
+//
+//	POST /accounts        CreateAccount
+//	GET  /accounts/{id}   GetAccount
+//	POST /transfers       CreateTransfer
+//	GET  /transfers/{id}  GetTransfer
+//
+// Bodies use the protobuf JSON mapping of the request and response messages.
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("POST /accounts", handle(g, "CreateAccount",
+		fromBody[*pb.CreateAccountRequest], g.service.CreateAccount))
+	mux.Handle("GET /accounts/{id}", handle(g, "GetAccount",
+		func(r *http.Request) (*pb.GetAccountRequest, error) {
+			return &pb.GetAccountRequest{Id: r.PathValue("id")}, nil
+		}, g.service.GetAccount))
+	mux.Handle("POST /transfers", handle(g, "CreateTransfer",
+		fromBody[*pb.CreateTransferRequest], g.service.CreateTransfer))
+	mux.Handle("GET /transfers/{id}", handle(g, "GetTransfer",
+		func(r *http.Request) (*pb.GetTransferRequest, error) {
+			return &pb.GetTransferRequest{Id: r.PathValue("id")}, nil
+		}, g.service.GetTransfer))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, status.Errorf(codes.NotFound, "no route for %s %s", r.Method, r.URL.Path))
+	})
+	return mux
+}
+
+// handle serves one RPC: it decodes the request, runs the method through
+// the interceptors and writes the response or the error as JSON
+func handle[Req, Resp proto.Message](g *Gateway, method string, decode func(*http.Request) (Req, error), invoke func(context.Context, Req) (Resp, error)) http.Handler {
+	info := &grpc.UnaryServerInfo{
+		Server:     g.service,
+		FullMethod: "/" + pb.FinancialService_ServiceDesc.ServiceName + "/" + method,
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, r.Pattern, trace.WithSpanKind(trace.SpanKindServer))
+		defer func() { tracing.End(span, err) }()
+
+		req, err := decode(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		stream := &transportStream{method: info.FullMethod}
+		ctx = grpc.NewContextWithServerTransportStream(incomingContext(ctx, r), stream)
+		resp, err := g.interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return invoke(ctx, req.(Req))
+		})
+
+		for key, values := range stream.header() {
+			for _, v := range values {
+				w.Header().Add(key, v)
+			}
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp.(proto.Message))
+	})
+}
+
+// fromBody decodes a JSON request body
+func fromBody[Req proto.Message](r *http.Request) (Req, error) {
+	var req Req
+	req = req.ProtoReflect().Type().New().Interface().(Req)
+
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	if err != nil {
+		return req, status.Errorf(codes.InvalidArgument, "failed to read body: %v", err)
+	}
+	if err := unmarshaler.Unmarshal(body, req); err != nil {
+		return req, status.Errorf(codes.InvalidArgument, "invalid JSON body: %v", err)
+	}
+	return req, nil
+}
+
+// incomingContext exposes the forwarded headers as incoming metadata and the
+// HTTP client, with its TLS state, as the peer
+func incomingContext(ctx context.Context, r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, key := range forwardedHeaders {
+		if values := r.Header.Values(key); len(values) > 0 {
+			md.Set(key, values...)
+		}
+	}
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	p := &peer.Peer{Addr: remoteAddr(r.RemoteAddr)}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{
+			State:          *r.TLS,
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}
+	}
+	return peer.NewContext(ctx, p)
+}
+
+type remoteAddr string
+
+func (a remoteAddr) Network() string { return "tcp" }
+func (a remoteAddr) String() string  { return string(a) }
+
+// transportStream collects the headers the interceptors set, e.g. the
+// request ID, to copy them to the HTTP response
+type transportStream struct {
+	method string
+
+	mu sync.Mutex
+	md metadata.MD
+}
+
+func (s *transportStream) Method() string {
+	return s.method
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.md = metadata.Join(s.md, md)
+	return nil
+}
+
+func (s *transportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *transportStream) SetTrailer(metadata.MD) error {
+	return nil
+}
+
+func (s *transportStream) header() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.md
+}
+
+// chain runs interceptors in order around a handler
+func chain(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, msg proto.Message) {
+	body, err := marshaler.Marshal(msg)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// writeError renders a gRPC status as a google.rpc.Status JSON body, e.g.
+//
+//	{"code":5, "message":"account not found", "details":[...]}
+//
+// with the HTTP status matching its code.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	body, merr := marshaler.Marshal(st.Proto())
+	if merr != nil {
+		body = []byte(fmt.Sprintf(`{"code":%d,"message":%q}`, codes.Internal, merr.Error()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(st.Code()))
+	_, _ = w.Write(body)
+}
+
+// HTTPStatus maps a gRPC code to the HTTP status used by grpc-gateway.
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,119 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/gateway"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/middleware"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requireToken rejects calls without an authorization header
+func requireToken(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get("authorization")) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+	return handler(ctx, req)
+}
+
+func newGateway(t *testing.T) *httptest.Server {
+	t.Helper()
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+
+	gw := gateway.New(service.NewFinancialService(repo), middleware.UnaryLogging(), requireToken)
+	server := httptest.NewServer(gw.Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// do sends a request and decodes the JSON response
+func do(t *testing.T, server *httptest.Server, method, path, body string) (*http.Response, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer token")
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(raw, &decoded), string(raw))
+	return resp, decoded
+}
+
+func TestGateway(t *testing.T) {
+	server := newGateway(t)
+
+	resp, debit := do(t, server, "POST", "/accounts", `{"ledger": 1, "code": 1, "user_data_64": "7"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode, debit)
+	assert.NotEmpty(t, resp.Header.Get(middleware.RequestIDKey))
+	assert.Equal(t, "7", debit["userData64"])
+
+	_, credit := do(t, server, "POST", "/accounts", `{"ledger": 1, "code": 1}`)
+
+	resp, account := do(t, server, "GET", "/accounts/"+debit["id"].(string), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, debit["id"], account["id"])
+
+	resp, transfer := do(t, server, "POST", "/transfers",
+		`{"debitAccountId": "`+debit["id"].(string)+`", "creditAccountId": "`+credit["id"].(string)+`", "amount": "10", "ledger": 1, "code": "1"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode, transfer)
+
+	resp, fetched := do(t, server, "GET", "/transfers/"+transfer["id"].(string), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "10", fetched["amount"])
+
+	t.Run("errors carry the gRPC status", func(t *testing.T) {
+		resp, body := do(t, server, "GET", "/accounts/424242", "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, float64(codes.NotFound), body["code"])
+		require.Len(t, body["details"], 1)
+		detail := body["details"].([]any)[0].(map[string]any)
+		assert.Equal(t, "ACCOUNT_NOT_FOUND", detail["reason"])
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		resp, body := do(t, server, "POST", "/accounts", `{"ledger": "one"}`)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, float64(codes.InvalidArgument), body["code"])
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		resp, body := do(t, server, "DELETE", "/accounts/1", "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Contains(t, body["message"], "no route")
+	})
+
+	t.Run("headers reach the interceptors", func(t *testing.T) {
+		resp, err := server.Client().Get(server.URL + "/accounts/1")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, gateway.HTTPStatus(codes.FailedPrecondition))
+	assert.Equal(t, http.StatusConflict, gateway.HTTPStatus(codes.AlreadyExists))
+	assert.Equal(t, http.StatusServiceUnavailable, gateway.HTTPStatus(codes.Unavailable))
+	assert.Equal(t, http.StatusInternalServerError, gateway.HTTPStatus(codes.DataLoss))
+}
@@ -0,0 +1,73 @@
+// Package health drives the standard grpc.health.v1 service from the
+// reachability of the TigerBeetle cluster.
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger performs a cheap request against the ledger.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Checker periodically pings the ledger and reports SERVING or NOT_SERVING
+// for the overall server and for each registered service.
+type Checker struct {
+	pinger   Pinger
+	server   *grpchealth.Server
+	services []string
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// NewChecker returns a checker that pings every interval, failing a ping
+// that takes longer than timeout. services are the fully qualified names
+// whose status follows the ledger, in addition to the overall "" status.
+func NewChecker(pinger Pinger, server *grpchealth.Server, interval, timeout time.Duration, services ...string) *Checker {
+	return &Checker{
+		pinger:   pinger,
+		server:   server,
+		services: append([]string{""}, services...),
+		interval: interval,
+		timeout:  timeout,
+	}
+}
+
+// Run checks immediately and then on every tick until ctx is done.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.Check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check(ctx)
+		}
+	}
+}
+
+// Check pings the ledger once and updates the status.
+func (c *Checker) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := c.pinger.Ping(ctx); err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+		logger.ErrorContext(ctx, "ledger health check failed", "error", err)
+	}
+
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,77 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakePinger struct {
+	down atomic.Bool
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	if p.down.Load() {
+		return errors.New("unreachable")
+	}
+	return nil
+}
+
+func status(t *testing.T, server *grpchealth.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func TestChecker(t *testing.T) {
+	const service = "financial.FinancialService"
+	pinger := &fakePinger{}
+	server := grpchealth.NewServer()
+	checker := health.NewChecker(pinger, server, time.Hour, time.Second, service)
+
+	checker.Check(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, server, service))
+
+	pinger.down.Store(true)
+	checker.Check(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, server, service))
+
+	pinger.down.Store(false)
+	checker.Check(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, server, service))
+}
+
+func TestCheckerRunStopsWithContext(t *testing.T) {
+	server := grpchealth.NewServer()
+	checker := health.NewChecker(&fakePinger{}, server, time.Millisecond, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		checker.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return status(t, server, "") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
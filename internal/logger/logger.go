@@ -1,26 +1,109 @@
+// Package logger wraps a zap sugared logger. Request-scoped loggers travel
+// in the context, so every line logged while serving an RPC carries its
+// request ID, method, peer and trace ID.
 package logger
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
-var log *zap.SugaredLogger
+// log discards everything until Init, Setup or Replace is called, so
+// packages used as a library or under test need no setup.
+var (
+	log   = zap.NewNop().Sugar()
+	level = zap.NewAtomicLevel()
+)
 
 func Init(isProduction bool) {
-	var logger *zap.Logger
-	var err error
-
+	var cfg zap.Config
 	if isProduction {
-		logger, err = zap.NewProduction()
+		cfg = zap.NewProductionConfig()
 	} else {
-		logger, err = zap.NewDevelopment()
+		cfg = zap.NewDevelopmentConfig()
 	}
+	level.SetLevel(cfg.Level.Level())
 
-	if err != nil {
+	if err := build(cfg); err != nil {
 		panic(err)
 	}
+}
+
+// Setup configures the logger with a level (debug, info, warn or error) and
+// a format (console or json). The level can be changed later with SetLevel.
+func Setup(levelName, format string) error {
+	var cfg zap.Config
+	switch format {
+	case "json":
+		cfg = zap.NewProductionConfig()
+	case "console":
+		cfg = zap.NewDevelopmentConfig()
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+
+	if err := SetLevel(levelName); err != nil {
+		return err
+	}
+	return build(cfg)
+}
+
+func build(cfg zap.Config) error {
+	cfg.Level = level
+	logger, err := cfg.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return redactCore{core}
+	}))
+	if err != nil {
+		return err
+	}
 
 	log = logger.Sugar()
+	return nil
+}
+
+// Replace makes l the global logger, keeping sensitive fields redacted. Its
+// level is fixed by l rather than by SetLevel.
+func Replace(l *zap.Logger) {
+	log = l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return redactCore{core}
+	})).Sugar()
+}
+
+// SetLevel changes the level of every logger, including the request-scoped
+// ones already in use.
+func SetLevel(name string) error {
+	l, err := zapcore.ParseLevel(name)
+	if err != nil {
+		return err
+	}
+	level.SetLevel(l)
+	return nil
+}
+
+// LevelHandler reports the current level on GET and changes it on PUT with
+// a body such as {"level":"debug"}.
+func LevelHandler() http.Handler {
+	return level
+}
+
+type contextKey struct{}
+
+// WithFields returns a context whose logger adds the given key-value pairs
+// to every line.
+func WithFields(ctx context.Context, fields ...interface{}) context.Context {
+	return context.WithValue(ctx, contextKey{}, FromContext(ctx).With(fields...))
+}
+
+// FromContext returns the logger carried by ctx, or the global one.
+func FromContext(ctx context.Context) *zap.SugaredLogger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.SugaredLogger); ok {
+		return l
+	}
+	return log
 }
 
 func Info(msg string, fields ...interface{}) {
@@ -34,3 +117,23 @@ func Error(msg string, fields ...interface{}) {
 func Debug(msg string, fields ...interface{}) {
 	log.Debugw(msg, fields...)
 }
+
+// InfoContext logs with the logger carried by ctx.
+func InfoContext(ctx context.Context, msg string, fields ...interface{}) {
+	FromContext(ctx).Infow(msg, fields...)
+}
+
+// WarnContext logs with the logger carried by ctx.
+func WarnContext(ctx context.Context, msg string, fields ...interface{}) {
+	FromContext(ctx).Warnw(msg, fields...)
+}
+
+// ErrorContext logs with the logger carried by ctx.
+func ErrorContext(ctx context.Context, msg string, fields ...interface{}) {
+	FromContext(ctx).Errorw(msg, fields...)
+}
+
+// DebugContext logs with the logger carried by ctx.
+func DebugContext(ctx context.Context, msg string, fields ...interface{}) {
+	FromContext(ctx).Debugw(msg, fields...)
+}
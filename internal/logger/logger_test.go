@@ -0,0 +1,73 @@
+package logger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func observe(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger.Replace(zap.New(core))
+	return logs
+}
+
+func TestContextLogger(t *testing.T) {
+	logs := observe(t)
+
+	ctx := logger.WithFields(context.Background(), "request_id", "abc")
+	ctx = logger.WithFields(ctx, "method", "/financial.FinancialService/GetAccount")
+	logger.InfoContext(ctx, "looking up", "id", 1)
+	logger.InfoContext(context.Background(), "no request")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{
+		"request_id": "abc",
+		"method":     "/financial.FinancialService/GetAccount",
+		"id":         int64(1),
+	}, entries[0].ContextMap())
+	assert.Empty(t, entries[1].ContextMap())
+}
+
+func TestRedaction(t *testing.T) {
+	logs := observe(t)
+
+	ctx := logger.WithFields(context.Background(), "authorization", "Bearer secret")
+	logger.InfoContext(ctx, "creating account", "user_data_128", "customer-42", "ledger", 1)
+
+	fields := logs.AllUntimed()[0].ContextMap()
+	assert.Equal(t, logger.Redacted, fields["authorization"])
+	assert.Equal(t, logger.Redacted, fields["user_data_128"])
+	assert.Equal(t, int64(1), fields["ledger"])
+}
+
+func TestSetLevel(t *testing.T) {
+	require.NoError(t, logger.Setup("info", "json"))
+	require.Error(t, logger.SetLevel("loud"))
+
+	handler := logger.LevelHandler()
+	req := httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	assert.JSONEq(t, `{"level":"debug"}`, rec.Body.String())
+
+	require.NoError(t, logger.SetLevel("warn"))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	assert.JSONEq(t, `{"level":"warn"}`, rec.Body.String())
+}
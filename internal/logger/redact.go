@@ -0,0 +1,56 @@
+package logger
+
+import "go.uber.org/zap/zapcore"
+
+// Redacted replaces the value of sensitive fields.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are field names whose values never reach the output. User
+// data often holds customer references, and authorization carries
+// credentials.
+var sensitiveKeys = map[string]bool{
+	"user_data":     true,
+	"user_data_128": true,
+	"user_data_64":  true,
+	"user_data_32":  true,
+	"authorization": true,
+}
+
+// redactCore masks sensitive fields, whether they are attached with With or
+// passed with a single entry
+type redactCore struct {
+	zapcore.Core
+}
+
+func (c redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactCore{c.Core.With(redact(fields))}
+}
+
+func (c redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, redact(fields))
+}
+
+func redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, field := range fields {
+		if !sensitiveKeys[field.Key] {
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, len(fields))
+			copy(out, fields)
+		}
+		out[i] = zapcore.Field{Key: field.Key, Type: zapcore.StringType, String: Redacted}
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+// Package metrics exports Prometheus metrics for the gRPC server and for the
+// requests the repository sends to TigerBeetle.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+)
+
+// Metrics holds every collector on a private registry. It implements
+// repository.Observer.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	handled  *prometheus.CounterVec
+	handling *prometheus.HistogramVec
+
+	batchSize       *prometheus.HistogramVec
+	requestDuration *prometheus.HistogramVec
+	results         *prometheus.CounterVec
+	clientErrors    *prometheus.CounterVec
+	transferred     *prometheus.CounterVec
+	coalescedSize   prometheus.Histogram
+	coalescedWait   prometheus.Histogram
+}
+
+var _ repository.Observer = (*Metrics)(nil)
+
+// New registers the collectors, along with the Go runtime and process
+// collectors, on a new registry.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "RPCs completed on the server, by method and status code.",
+		}, []string{"grpc_method", "grpc_code"}),
+		handling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Time taken by the server to handle an RPC.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"grpc_method"}),
+		batchSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "tigerbeetle_request_batch_size",
+			Help:    "Events per request sent to TigerBeetle.",
+			Buckets: prometheus.ExponentialBuckets(1, 4, 7),
+		}, []string{"operation"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "tigerbeetle_request_duration_seconds",
+			Help:    "Time taken by a request to TigerBeetle.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation"}),
+		results: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tigerbeetle_results_total",
+			Help: "Events created in TigerBeetle, by result.",
+		}, []string{"operation", "result"}),
+		clientErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tigerbeetle_client_errors_total",
+			Help: "Requests to TigerBeetle that failed in the client.",
+		}, []string{"operation"}),
+		transferred: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "tigerbeetle_transferred_amount_total",
+			Help: "Amount posted by successful transfers, by ledger; pending amounts count when posted.",
+		}, []string{"ledger"}),
+		coalescedSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "tigerbeetle_coalesced_batch_size",
+			Help:    "CreateTransfer calls coalesced into a single request to TigerBeetle.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		coalescedWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "tigerbeetle_coalesced_wait_seconds",
+			Help:    "Time the first call of a coalesced batch waited before it was sent.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 2, 12),
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.handled, m.handling,
+		m.batchSize, m.requestDuration, m.results, m.clientErrors, m.transferred,
+		m.coalescedSize, m.coalescedWait,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// UnaryInterceptor records the outcome and latency of unary RPCs.
+func (m *Metrics) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor records the outcome and duration of streaming RPCs.
+func (m *Metrics) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (m *Metrics) observeRPC(method string, start time.Time, err error) {
+	m.handling.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	m.handled.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+// ObserveRequest implements repository.Observer.
+func (m *Metrics) ObserveRequest(operation string, size int, duration time.Duration, err error) {
+	m.batchSize.WithLabelValues(operation).Observe(float64(size))
+	m.requestDuration.WithLabelValues(operation).Observe(duration.Seconds())
+	if err != nil {
+		m.clientErrors.WithLabelValues(operation).Inc()
+	}
+}
+
+// ObserveResults implements repository.Observer.
+func (m *Metrics) ObserveResults(operation string, results map[string]int) {
+	for result, n := range results {
+		if n > 0 {
+			m.results.WithLabelValues(operation, result).Add(float64(n))
+		}
+	}
+}
+
+// ObserveTransferred implements repository.Observer. Amounts above 2^53 lose
+// precision, as every Prometheus value is a float64.
+func (m *Metrics) ObserveTransferred(ledger uint32, amount tb_types.Uint128) {
+	value, _ := strconv.ParseFloat(tbutil.Uint128ToString(amount), 64)
+	m.transferred.WithLabelValues(strconv.FormatUint(uint64(ledger), 10)).Add(value)
+}
+
+// ObserveBatch implements repository.Observer.
+func (m *Metrics) ObserveBatch(size int, wait time.Duration) {
+	m.coalescedSize.Observe(float64(size))
+	m.coalescedWait.Observe(wait.Seconds())
+}
@@ -0,0 +1,184 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/metrics"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/service"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves the financial service over an in-memory listener with
+// the metrics interceptors and observer installed.
+func startServer(t *testing.T, m *metrics.Metrics) (pb.FinancialServiceClient, *repository.TigerBeetleRepository) {
+	t.Helper()
+	repo := repository.NewInMemoryRepository(repository.WithObserver(m))
+	t.Cleanup(repo.Close)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(m.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(m.StreamInterceptor()),
+	)
+	pb.RegisterFinancialServiceServer(server, service.NewFinancialService(repo))
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewFinancialServiceClient(conn), repo
+}
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	srv := httptest.NewServer(m.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	client, repo := startServer(t, m)
+
+	ids := make([]string, 2)
+	for i := range ids {
+		account := tb_types.Account{ID: tb_types.ID(), UserData128: tb_types.ToUint128(1), Ledger: 7, Code: 1}
+		_, err := repo.CreateAccount(ctx, account)
+		require.NoError(t, err)
+		ids[i] = tbutil.Uint128ToString(account.ID)
+	}
+
+	_, err := client.CreateTransfer(ctx, &pb.CreateTransferRequest{
+		DebitAccountId: ids[0], CreditAccountId: ids[1], Amount: "250", Ledger: 7, Code: "1",
+	})
+	require.NoError(t, err)
+
+	pending, err := client.ReserveFunds(ctx, &pb.ReserveFundsRequest{
+		DebitAccountId: ids[0], CreditAccountId: ids[1], Amount: "100", Ledger: 7, Code: "1",
+	})
+	require.NoError(t, err)
+	_, err = client.CapturePending(ctx, &pb.CapturePendingRequest{PendingId: pending.Id})
+	require.NoError(t, err)
+
+	_, err = client.CreateTransfer(ctx, &pb.CreateTransferRequest{
+		DebitAccountId: ids[0], CreditAccountId: "424242", Amount: "1", Ledger: 7, Code: "1",
+	})
+	require.Error(t, err)
+
+	_, err = client.GetAccount(ctx, &pb.GetAccountRequest{Id: "0"})
+	require.Error(t, err)
+
+	body := scrape(t, m)
+
+	t.Run("rpcs", func(t *testing.T) {
+		assert.Contains(t, body, `grpc_server_handled_total{grpc_code="OK",grpc_method="/financial.FinancialService/CreateTransfer"} 1`)
+		assert.Contains(t, body, `grpc_server_handled_total{grpc_code="NotFound",grpc_method="/financial.FinancialService/CreateTransfer"} 1`)
+		assert.Contains(t, body, `grpc_server_handled_total{grpc_code="InvalidArgument",grpc_method="/financial.FinancialService/GetAccount"} 1`)
+		assert.Contains(t, body, `grpc_server_handling_seconds_count{grpc_method="/financial.FinancialService/CreateTransfer"} 2`)
+	})
+
+	t.Run("ledger requests", func(t *testing.T) {
+		assert.Contains(t, body, `tigerbeetle_results_total{operation="create_accounts",result="ok"} 2`)
+		assert.Contains(t, body, `tigerbeetle_results_total{operation="create_transfers",result="ok"} 3`)
+		assert.Contains(t, body, `tigerbeetle_results_total{operation="create_transfers",result="credit_account_not_found"} 1`)
+		assert.Contains(t, body, `tigerbeetle_request_batch_size_count{operation="create_transfers"} 4`)
+		assert.Contains(t, body, `tigerbeetle_request_duration_seconds_count{operation="lookup_transfers"}`)
+		assert.NotContains(t, body, `tigerbeetle_client_errors_total{`)
+	})
+
+	t.Run("transferred amount counts the post, not the reservation", func(t *testing.T) {
+		assert.Contains(t, body, `tigerbeetle_transferred_amount_total{ledger="7"} 350`)
+	})
+}
+
+func TestTransferredAmount(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.New()
+	client, repo := startServer(t, m)
+
+	ids := make([]string, 2)
+	for i := range ids {
+		account := tb_types.Account{ID: tb_types.ID(), UserData128: tb_types.ToUint128(1), Ledger: 7, Code: 1}
+		_, err := repo.CreateAccount(ctx, account)
+		require.NoError(t, err)
+		ids[i] = tbutil.Uint128ToString(account.ID)
+	}
+
+	_, err := client.CreateTransfer(ctx, &pb.CreateTransferRequest{
+		DebitAccountId: ids[0], CreditAccountId: ids[1], Amount: "250", Ledger: 7, Code: "1",
+	})
+	require.NoError(t, err)
+
+	// A partial capture counts what was captured
+	pending, err := client.ReserveFunds(ctx, &pb.ReserveFundsRequest{
+		DebitAccountId: ids[0], CreditAccountId: ids[1], Amount: "100", Ledger: 7, Code: "1",
+	})
+	require.NoError(t, err)
+	_, err = client.CapturePending(ctx, &pb.CapturePendingRequest{PendingId: pending.Id, Amount: "40"})
+	require.NoError(t, err)
+
+	// A voided reservation counts nothing
+	pending, err = client.ReserveFunds(ctx, &pb.ReserveFundsRequest{
+		DebitAccountId: ids[0], CreditAccountId: ids[1], Amount: "30", Ledger: 7, Code: "1",
+	})
+	require.NoError(t, err)
+	_, err = client.VoidPending(ctx, &pb.VoidPendingRequest{PendingId: pending.Id})
+	require.NoError(t, err)
+
+	// A balancing debit counts the 290 the debit account holds, not 1000
+	_, err = client.CreateTransfer(ctx, &pb.CreateTransferRequest{
+		DebitAccountId: ids[1], CreditAccountId: ids[0], Amount: "1000", Ledger: 7, Code: "1",
+		Flags: uint32(tb_types.TransferFlags{BalancingDebit: true}.ToUint16()),
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, scrape(t, m), `tigerbeetle_transferred_amount_total{ledger="7"} 580`)
+}
+
+type failingClient struct {
+	*repository.MemoryClient
+}
+
+func (failingClient) LookupAccounts([]tb_types.Uint128) ([]tb_types.Account, error) {
+	return nil, io.ErrUnexpectedEOF
+}
+
+func TestClientErrors(t *testing.T) {
+	m := metrics.New()
+	repo := repository.NewRepositoryWithClient(failingClient{repository.NewMemoryClient()}, repository.WithObserver(m))
+	defer repo.Close()
+
+	_, err := repo.GetAccount(context.Background(), tb_types.ToUint128(1))
+	require.Error(t, err)
+
+	assert.Contains(t, scrape(t, m), `tigerbeetle_client_errors_total{operation="lookup_accounts"} 1`)
+}
@@ -0,0 +1,75 @@
+// Package middleware holds the gRPC interceptors installed by the server.
+package middleware
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Call describes an RPC that has started but not yet returned.
+type Call struct {
+	Method  string
+	Started time.Time
+}
+
+// Tracker records the RPCs currently being served, so that a shutdown that
+// runs out of time can report what it cut off.
+type Tracker struct {
+	mu    sync.Mutex
+	next  uint64
+	calls map[uint64]Call
+}
+
+// NewTracker returns an empty tracker.
+func NewTracker() *Tracker {
+	return &Tracker{calls: make(map[uint64]Call)}
+}
+
+func (t *Tracker) begin(method string) func() {
+	t.mu.Lock()
+	id := t.next
+	t.next++
+	t.calls[id] = Call{Method: method, Started: time.Now()}
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		delete(t.calls, id)
+		t.mu.Unlock()
+	}
+}
+
+// InFlight returns the calls in progress, oldest first.
+func (t *Tracker) InFlight() []Call {
+	t.mu.Lock()
+	calls := make([]Call, 0, len(t.calls))
+	for _, call := range t.calls {
+		calls = append(calls, call)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].Started.Before(calls[j].Started)
+	})
+	return calls
+}
+
+// UnaryInterceptor tracks unary RPCs.
+func (t *Tracker) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		defer t.begin(info.FullMethod)()
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor tracks streaming RPCs.
+func (t *Tracker) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		defer t.begin(info.FullMethod)()
+		return handler(srv, ss)
+	}
+}
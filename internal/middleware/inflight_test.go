@@ -0,0 +1,39 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := middleware.NewTracker()
+	interceptor := tracker.UnaryInterceptor()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		info := &grpc.UnaryServerInfo{FullMethod: "/financial.FinancialService/CreateTransfer"}
+		_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			close(started)
+			<-release
+			return nil, nil
+		})
+	}()
+
+	<-started
+	calls := tracker.InFlight()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "/financial.FinancialService/CreateTransfer", calls[0].Method)
+	assert.False(t, calls[0].Started.IsZero())
+
+	close(release)
+	<-done
+	assert.Empty(t, tracker.InFlight())
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the metadata key of the request ID. A caller may send one
+// to correlate its own logs; otherwise the server generates it. Either way
+// it is returned in the response header.
+const RequestIDKey = "x-request-id"
+
+// maxRequestIDLength bounds caller-supplied request IDs
+const maxRequestIDLength = 128
+
+// UnaryLogging puts a request-scoped logger in the context of unary RPCs and
+// logs their outcome.
+func UnaryLogging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, requestID := withRequestLogger(ctx, info.FullMethod)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCompletion(ctx, start, err)
+		return resp, err
+	}
+}
+
+// StreamLogging puts a request-scoped logger in the context of streaming
+// RPCs and logs their outcome.
+func StreamLogging() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequestLogger(ss.Context(), info.FullMethod)
+		_ = ss.SetHeader(metadata.Pairs(RequestIDKey, requestID))
+
+		start := time.Now()
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logCompletion(ctx, start, err)
+		return err
+	}
+}
+
+// contextStream replaces the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func withRequestLogger(ctx context.Context, method string) (context.Context, string) {
+	requestID := incomingRequestID(ctx)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+
+	fields := []interface{}{"request_id", requestID, "method", method}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, "peer", p.Addr.String())
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		fields = append(fields, "trace_id", sc.TraceID().String())
+	}
+	return logger.WithFields(ctx, fields...), requestID
+}
+
+func incomingRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(RequestIDKey)
+	if len(values) == 0 || len(values[0]) > maxRequestIDLength {
+		return ""
+	}
+	return values[0]
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// logCompletion logs the outcome of an RPC, at error level when the server
+// is at fault
+func logCompletion(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []interface{}{"code", code.String(), "duration", time.Since(start)}
+	if err != nil {
+		fields = append(fields, "error", status.Convert(err).Message())
+	}
+
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		logger.ErrorContext(ctx, "rpc failed", fields...)
+	default:
+		logger.InfoContext(ctx, "rpc completed", fields...)
+	}
+}
@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryLogging(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger.Replace(zap.New(core))
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+
+	ctx := trace.ContextWithSpanContext(context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 4242}})
+
+	interceptor := middleware.UnaryLogging()
+	info := &grpc.UnaryServerInfo{FullMethod: "/financial.FinancialService/GetAccount"}
+	call := func(ctx context.Context, err error) {
+		_, _ = interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			logger.InfoContext(ctx, "inside handler")
+			return nil, err
+		})
+	}
+
+	t.Run("caller request ID", func(t *testing.T) {
+		logs.TakeAll()
+		md := metadata.Pairs(middleware.RequestIDKey, "req-1")
+		call(metadata.NewIncomingContext(ctx, md), status.Error(codes.NotFound, "account not found"))
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			fields := entry.ContextMap()
+			assert.Equal(t, "req-1", fields["request_id"])
+			assert.Equal(t, info.FullMethod, fields["method"])
+			assert.Equal(t, "10.0.0.7:4242", fields["peer"])
+			assert.Equal(t, traceID.String(), fields["trace_id"])
+		}
+		assert.Equal(t, "rpc completed", entries[1].Message)
+		assert.Equal(t, "NotFound", entries[1].ContextMap()["code"])
+	})
+
+	t.Run("generated request ID", func(t *testing.T) {
+		logs.TakeAll()
+		call(ctx, status.Error(codes.Internal, "boom"))
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 2)
+		assert.Len(t, entries[0].ContextMap()["request_id"], 16)
+		assert.Equal(t, entries[0].ContextMap()["request_id"], entries[1].ContextMap()["request_id"])
+		assert.Equal(t, zapcore.ErrorLevel, entries[1].Level)
+	})
+}
@@ -0,0 +1,64 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecovery(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger.Replace(zap.New(core))
+
+	t.Run("unary", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/financial.FinancialService/GetAccount"}
+		var err error
+		assert.NotPanics(t, func() {
+			_, err = middleware.UnaryRecovery()(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+				var b []byte
+				return b[15], nil
+			})
+		})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		info := &grpc.StreamServerInfo{FullMethod: "/financial.FinancialService/ImportAccounts"}
+		var err error
+		assert.NotPanics(t, func() {
+			err = middleware.StreamRecovery()(nil, &contextStream{ctx: context.Background()}, info, func(srv any, ss grpc.ServerStream) error {
+				panic("boom")
+			})
+		})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("errors pass through", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{FullMethod: "/financial.FinancialService/GetAccount"}
+		_, err := middleware.UnaryRecovery()(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, status.Error(codes.NotFound, "missing")
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	assert.Equal(t, 2, logs.FilterMessage("panic serving request").Len())
+}
+
+// contextStream is a server stream with only a context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/validation"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// CreateAccounts creates many accounts, splitting them into client requests
+// of at most MaxBatchSize events without breaking linked chains. It returns
+// one result per account, indexed like the input. If a request fails, the
+// earlier ones stay committed: their results, a prefix of the input, are
+// returned with the error.
+func (r *TigerBeetleRepository) CreateAccounts(ctx context.Context, accounts []tb_types.Account) (_ []tb_types.CreateAccountResult, err error) {
+	ctx, span := startSpan(ctx, "CreateAccounts", tracing.BatchSize(len(accounts)))
+	defer func() { endSpan(span, err) }()
+
+	for i, account := range accounts {
+		if err := validation.ValidateAccount(account); err != nil {
+			logger.ErrorContext(ctx, "account validation failed", "error", err, "index", i)
+			return nil, &ValidationError{Err: fmt.Errorf("account %d: %w", i, err)}
+		}
+	}
+
+	ranges, err := chunks(len(accounts), MaxBatchSize, func(i int) bool {
+		return accounts[i].AccountFlags().Linked
+	})
+	if err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	codes := make([]tb_types.CreateAccountResult, len(accounts))
+	for _, rg := range ranges {
+		logger.InfoContext(ctx, "creating accounts", "count", rg[1]-rg[0], "offset", rg[0])
+
+		results, err := r.client.CreateAccounts(accounts[rg[0]:rg[1]])
+		if err != nil {
+			logger.ErrorContext(ctx, "error creating accounts", "error", err, "offset", rg[0])
+			return codes[:rg[0]], fmt.Errorf("failed to create accounts %d-%d: %w", rg[0], rg[1]-1, err)
+		}
+		for _, result := range results {
+			codes[rg[0]+int(result.Index)] = result.Result
+		}
+	}
+
+	return codes, nil
+}
+
+// CreateTransfers creates many transfers, splitting them into client requests
+// of at most MaxBatchSize events without breaking linked chains. It returns
+// one result per transfer, indexed like the input. If a request fails, the
+// earlier ones stay committed: their results, a prefix of the input, are
+// returned with the error.
+func (r *TigerBeetleRepository) CreateTransfers(ctx context.Context, transfers []tb_types.Transfer) (_ []tb_types.CreateTransferResult, err error) {
+	ctx, span := startSpan(ctx, "CreateTransfers", tracing.BatchSize(len(transfers)))
+	defer func() { endSpan(span, err) }()
+
+	for i, transfer := range transfers {
+		if err := validation.ValidateTransfer(transfer); err != nil {
+			logger.ErrorContext(ctx, "transfer validation failed", "error", err, "index", i)
+			return nil, &ValidationError{Err: fmt.Errorf("transfer %d: %w", i, err)}
+		}
+	}
+
+	ranges, err := chunks(len(transfers), MaxBatchSize, func(i int) bool {
+		return transfers[i].TransferFlags().Linked
+	})
+	if err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+
+	codes := make([]tb_types.CreateTransferResult, len(transfers))
+	for _, rg := range ranges {
+		logger.InfoContext(ctx, "creating transfers", "count", rg[1]-rg[0], "offset", rg[0])
+
+		results, err := r.client.CreateTransfers(transfers[rg[0]:rg[1]])
+		if err != nil {
+			logger.ErrorContext(ctx, "error creating transfers", "error", err, "offset", rg[0])
+			return codes[:rg[0]], fmt.Errorf("failed to create transfers %d-%d: %w", rg[0], rg[1]-1, err)
+		}
+		for _, result := range results {
+			codes[rg[0]+int(result.Index)] = result.Result
+		}
+	}
+
+	return codes, nil
+}
+
+// chunks splits n events into consecutive [start, end) ranges of at most size
+// events. A range never ends on a linked event, so linked chains are always
+// submitted in a single request.
+func chunks(n, size int, linked func(int) bool) ([][2]int, error) {
+	var ranges [][2]int
+	for start := 0; start < n; {
+		end := min(start+size, n)
+		if end < n {
+			for end > start && linked(end-1) {
+				end--
+			}
+			if end == start {
+				return nil, fmt.Errorf("linked chain starting at %d is longer than %d events", start, size)
+			}
+		}
+		ranges = append(ranges, [2]int{start, end})
+		start = end
+	}
+	return ranges, nil
+}
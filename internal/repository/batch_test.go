@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunks(t *testing.T) {
+	notLinked := func(int) bool { return false }
+
+	t.Run("splits at size", func(t *testing.T) {
+		ranges, err := chunks(7, 3, notLinked)
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]int{{0, 3}, {3, 6}, {6, 7}}, ranges)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		ranges, err := chunks(0, 3, notLinked)
+		assert.NoError(t, err)
+		assert.Empty(t, ranges)
+	})
+
+	t.Run("keeps linked chains together", func(t *testing.T) {
+		// Events 1 and 2 are linked to the event that follows them.
+		linked := func(i int) bool { return i == 1 || i == 2 }
+		ranges, err := chunks(6, 3, linked)
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]int{{0, 1}, {1, 4}, {4, 6}}, ranges)
+	})
+
+	t.Run("chain longer than size", func(t *testing.T) {
+		linked := func(i int) bool { return i < 4 }
+		_, err := chunks(6, 3, linked)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// WithTransferBatching coalesces concurrent CreateTransfer calls into shared
+// client requests, trading up to window of latency for fewer, fuller
+// requests under load. A request is sent once it holds maxSize transfers or
+// window after its first transfer arrived, whichever comes first. Linked and
+// imported transfers are always sent on their own, as they cannot share a
+// request with unrelated transfers.
+func WithTransferBatching(window time.Duration, maxSize int) Option {
+	return func(r *TigerBeetleRepository) {
+		r.batchWindow = window
+		r.batchSize = min(max(maxSize, 1), MaxBatchSize)
+	}
+}
+
+// transferBatcher collects transfers submitted concurrently and sends them
+// to the cluster together, handing each caller the result of its own event.
+type transferBatcher struct {
+	client   Client
+	observer Observer
+	window   time.Duration
+	maxSize  int
+
+	// queue is unbuffered, so a transfer is either picked up by run or not
+	// queued at all once the batcher stops
+	queue    chan *queuedTransfer
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	inFlight sync.WaitGroup
+}
+
+type queuedTransfer struct {
+	ctx      context.Context
+	transfer tb_types.Transfer
+	queued   time.Time
+	result   chan batchResult
+}
+
+type batchResult struct {
+	result tb_types.CreateTransferResult
+	err    error
+}
+
+func newTransferBatcher(client Client, observer Observer, window time.Duration, maxSize int) *transferBatcher {
+	b := &transferBatcher{
+		client:   client,
+		observer: observer,
+		window:   window,
+		maxSize:  maxSize,
+		queue:    make(chan *queuedTransfer),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// accepts reports whether a transfer may share a request with others
+func (b *transferBatcher) accepts(transfer tb_types.Transfer) bool {
+	flags := transfer.TransferFlags()
+	return !flags.Linked && !flags.Imported
+}
+
+// submit queues a transfer and waits for its result. A caller whose context
+// ends before the batch is sent is left out of it; once sent, the transfer
+// may be created even though the caller has stopped waiting.
+func (b *transferBatcher) submit(ctx context.Context, transfer tb_types.Transfer) (tb_types.CreateTransferResult, error) {
+	q := &queuedTransfer{
+		ctx:      ctx,
+		transfer: transfer,
+		queued:   time.Now(),
+		result:   make(chan batchResult, 1),
+	}
+
+	select {
+	case b.queue <- q:
+	case <-b.stop:
+		return 0, ErrClientClosed
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	select {
+	case r := <-q.result:
+		return r.result, r.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (b *transferBatcher) run() {
+	defer close(b.done)
+	for {
+		var first *queuedTransfer
+		select {
+		case first = <-b.queue:
+		case <-b.stop:
+			return
+		}
+
+		batch := []*queuedTransfer{first}
+		timer := time.NewTimer(b.window)
+	collect:
+		for len(batch) < b.maxSize {
+			select {
+			case q := <-b.queue:
+				batch = append(batch, q)
+			case <-timer.C:
+				break collect
+			case <-b.stop:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		// Batches are sent concurrently, so a slow request does not hold up
+		// the next one
+		b.inFlight.Add(1)
+		go func() {
+			defer b.inFlight.Done()
+			b.flush(batch)
+		}()
+	}
+}
+
+func (b *transferBatcher) flush(batch []*queuedTransfer) {
+	pending := batch[:0]
+	for _, q := range batch {
+		if err := q.ctx.Err(); err != nil {
+			q.result <- batchResult{err: err}
+			continue
+		}
+		pending = append(pending, q)
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	transfers := make([]tb_types.Transfer, len(pending))
+	for i, q := range pending {
+		transfers[i] = q.transfer
+	}
+	if b.observer != nil {
+		b.observer.ObserveBatch(len(pending), time.Since(pending[0].queued))
+	}
+
+	results, err := b.client.CreateTransfers(transfers)
+	if err != nil {
+		for _, q := range pending {
+			q.result <- batchResult{err: err}
+		}
+		return
+	}
+
+	codes := make([]tb_types.CreateTransferResult, len(pending))
+	for _, result := range results {
+		codes[result.Index] = result.Result
+	}
+	for i, q := range pending {
+		q.result <- batchResult{result: codes[i]}
+	}
+}
+
+// close sends the transfers already queued and waits for every batch in
+// flight. Later submissions fail with ErrClientClosed.
+func (b *transferBatcher) close() {
+	b.stopOnce.Do(func() { close(b.stop) })
+	<-b.done
+	b.inFlight.Wait()
+}
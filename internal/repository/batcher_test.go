@@ -0,0 +1,130 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// countingClient records the size of every CreateTransfers request
+type countingClient struct {
+	*repository.MemoryClient
+	mu    sync.Mutex
+	sizes []int
+}
+
+func (c *countingClient) CreateTransfers(transfers []tb_types.Transfer) ([]tb_types.TransferEventResult, error) {
+	c.mu.Lock()
+	c.sizes = append(c.sizes, len(transfers))
+	c.mu.Unlock()
+	return c.MemoryClient.CreateTransfers(transfers)
+}
+
+func (c *countingClient) requests() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]int(nil), c.sizes...)
+}
+
+// batchObserver records the batches reported by the repository
+type batchObserver struct {
+	mu      sync.Mutex
+	batches []int
+}
+
+func (o *batchObserver) ObserveRequest(string, int, time.Duration, error) {}
+func (o *batchObserver) ObserveResults(string, map[string]int)            {}
+func (o *batchObserver) ObserveTransferred(uint32, tb_types.Uint128)      {}
+func (o *batchObserver) ObserveBatch(size int, wait time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.batches = append(o.batches, size)
+}
+
+func newBatchingRepository(t *testing.T, window time.Duration, size int, opts ...repository.Option) (*repository.TigerBeetleRepository, *countingClient) {
+	t.Helper()
+	client := &countingClient{MemoryClient: repository.NewMemoryClient()}
+	_, err := client.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(2, 1, tb_types.AccountFlags{}),
+	})
+	require.NoError(t, err)
+
+	opts = append(opts, repository.WithTransferBatching(window, size))
+	repo := repository.NewRepositoryWithClient(client, opts...)
+	t.Cleanup(repo.Close)
+	return repo, client
+}
+
+func TestTransferBatching(t *testing.T) {
+	t.Run("coalesces concurrent calls and demultiplexes results", func(t *testing.T) {
+		observer := &batchObserver{}
+		const n = 8
+		repo, client := newBatchingRepository(t, time.Hour, n, repository.WithObserver(observer))
+
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		for i := range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				credit := uint64(2)
+				if i == 3 {
+					credit = 9 // unknown account
+				}
+				_, errs[i] = repo.CreateTransfer(context.Background(), newTransfer(uint64(100+i), 1, credit, 1, tb_types.TransferFlags{}))
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, []int{n}, client.requests())
+		assert.Equal(t, []int{n}, observer.batches)
+		for i, err := range errs {
+			if i == 3 {
+				var transferErr *repository.TransferError
+				require.ErrorAs(t, err, &transferErr)
+				assert.Equal(t, tb_types.TransferCreditAccountNotFound, transferErr.Result)
+				continue
+			}
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("sends a partial batch after the window", func(t *testing.T) {
+		repo, client := newBatchingRepository(t, 10*time.Millisecond, 100)
+
+		_, err := repo.CreateTransfer(context.Background(), newTransfer(100, 1, 2, 1, tb_types.TransferFlags{}))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, client.requests())
+	})
+
+	t.Run("caller giving up is left out of the batch", func(t *testing.T) {
+		repo, client := newBatchingRepository(t, time.Hour, 100)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := repo.CreateTransfer(ctx, newTransfer(100, 1, 2, 1, tb_types.TransferFlags{}))
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "%v", err)
+
+		// Closing sends what is queued; the abandoned transfer is not
+		repo.Close()
+		assert.Empty(t, client.requests())
+	})
+
+	t.Run("linked transfers bypass the batcher", func(t *testing.T) {
+		repo, client := newBatchingRepository(t, time.Hour, 100)
+
+		_, err := repo.CreateTransfer(context.Background(), newTransfer(100, 1, 2, 1, tb_types.TransferFlags{Linked: true}))
+		var transferErr *repository.TransferError
+		require.ErrorAs(t, err, &transferErr)
+		assert.Equal(t, tb_types.TransferLinkedEventChainOpen, transferErr.Result)
+		assert.Equal(t, []int{1}, client.requests())
+	})
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+var (
+	// ErrAccountNotFound is returned when looking up an account that does not exist.
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrTransferNotFound is returned when looking up a transfer that does not exist.
+	ErrTransferNotFound = errors.New("transfer not found")
+)
+
+// ValidationError is returned when an account or transfer is rejected before
+// it is sent to the cluster.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// AccountError is returned when the cluster rejects an account.
+type AccountError struct {
+	Result tb_types.CreateAccountResult
+}
+
+func (e *AccountError) Error() string {
+	return "account creation failed: " + tbutil.AccountResultName(e.Result)
+}
+
+// TransferError is returned when the cluster rejects a transfer.
+type TransferError struct {
+	Result tb_types.CreateTransferResult
+}
+
+func (e *TransferError) Error() string {
+	return "transfer creation failed: " + tbutil.TransferResultName(e.Result)
+}
+
+// IsConflict reports whether err is an exists_with_different_* result, i.e.
+// the requested ID already belongs to an object with different fields.
+func IsConflict(err error) bool {
+	var accountErr *AccountError
+	if errors.As(err, &accountErr) {
+		return strings.HasPrefix(tbutil.AccountResultName(accountErr.Result), "exists_with_different_")
+	}
+
+	var transferErr *TransferError
+	if errors.As(err, &transferErr) {
+		return strings.HasPrefix(tbutil.TransferResultName(transferErr.Result), "exists_with_different_")
+	}
+
+	return false
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	tb "github.com/tigerbeetle/tigerbeetle-go"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Ledger is the set of ledger operations the service layer depends on.
+// TigerBeetleRepository implements it on top of any Client, which lets the
+// service run against a real cluster or against the in-memory MemoryClient.
+type Ledger interface {
+	CreateAccount(ctx context.Context, account tb_types.Account) (*tb_types.Account, error)
+	GetAccount(ctx context.Context, id tb_types.Uint128) (*tb_types.Account, error)
+	CreateTransfer(ctx context.Context, transfer tb_types.Transfer) (*tb_types.Transfer, error)
+	GetTransfer(ctx context.Context, id tb_types.Uint128) (*tb_types.Transfer, error)
+	CreateLinkedTransfers(ctx context.Context, transfers []tb_types.Transfer) ([]tb_types.CreateTransferResult, error)
+	CreateAccounts(ctx context.Context, accounts []tb_types.Account) ([]tb_types.CreateAccountResult, error)
+	CreateTransfers(ctx context.Context, transfers []tb_types.Transfer) ([]tb_types.CreateTransferResult, error)
+	GetAccountTransfers(ctx context.Context, filter tb_types.AccountFilter) ([]tb_types.Transfer, error)
+	GetAccountBalances(ctx context.Context, filter tb_types.AccountFilter) ([]tb_types.AccountBalance, error)
+	QueryAccounts(ctx context.Context, filter tb_types.QueryFilter) ([]tb_types.Account, error)
+	QueryTransfers(ctx context.Context, filter tb_types.QueryFilter) ([]tb_types.Transfer, error)
+	Close()
+}
+
+// Client is the subset of the TigerBeetle client API used by the repository.
+type Client interface {
+	CreateAccounts(accounts []tb_types.Account) ([]tb_types.AccountEventResult, error)
+	CreateTransfers(transfers []tb_types.Transfer) ([]tb_types.TransferEventResult, error)
+	LookupAccounts(accountIDs []tb_types.Uint128) ([]tb_types.Account, error)
+	LookupTransfers(transferIDs []tb_types.Uint128) ([]tb_types.Transfer, error)
+	GetAccountTransfers(filter tb_types.AccountFilter) ([]tb_types.Transfer, error)
+	GetAccountBalances(filter tb_types.AccountFilter) ([]tb_types.AccountBalance, error)
+	QueryAccounts(filter tb_types.QueryFilter) ([]tb_types.Account, error)
+	QueryTransfers(filter tb_types.QueryFilter) ([]tb_types.Transfer, error)
+	Close()
+}
+
+var (
+	_ Ledger = (*TigerBeetleRepository)(nil)
+	_ Client = tb.Client(nil)
+	_ Client = (*MemoryClient)(nil)
+)
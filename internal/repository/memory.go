@@ -0,0 +1,992 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/validation"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// ErrClientClosed is returned by MemoryClient once Close has been called.
+var ErrClientClosed = errors.New("client closed")
+
+var (
+	accountFlagsMask = tb_types.AccountFlags{
+		Linked:                     true,
+		DebitsMustNotExceedCredits: true,
+		CreditsMustNotExceedDebits: true,
+		History:                    true,
+		Imported:                   true,
+		Closed:                     true,
+	}.ToUint16()
+
+	transferFlagsMask = tb_types.TransferFlags{
+		Linked:              true,
+		Pending:             true,
+		PostPendingTransfer: true,
+		VoidPendingTransfer: true,
+		BalancingDebit:      true,
+		BalancingCredit:     true,
+		ClosingDebit:        true,
+		ClosingCredit:       true,
+		Imported:            true,
+	}.ToUint16()
+
+	accountClosedFlag = tb_types.AccountFlags{Closed: true}.ToUint16()
+)
+
+// maxTimestamp is the largest timestamp an imported event may carry.
+const maxTimestamp = 1<<63 - 1
+
+// historyEntry is an account balance snapshot taken after a transfer, for
+// accounts created with the History flag.
+type historyEntry struct {
+	balance tb_types.AccountBalance
+	debit   bool
+}
+
+type pendingState int
+
+const (
+	pendingActive pendingState = iota
+	pendingPosted
+	pendingVoided
+	pendingExpired
+)
+
+// MemoryClient is an in-memory stand-in for the TigerBeetle client. It applies
+// the same validation order, result codes, flag rules, linked chains and
+// pending/posted bookkeeping as a cluster, so the service can be exercised in
+// unit tests and local development without a running TigerBeetle.
+//
+// MemoryClient is safe for concurrent use.
+type MemoryClient struct {
+	mu        sync.Mutex
+	closed    bool
+	timestamp uint64
+
+	accounts  map[tb_types.Uint128]*tb_types.Account
+	transfers map[tb_types.Uint128]*tb_types.Transfer
+	pending   map[tb_types.Uint128]pendingState
+	failed    map[tb_types.Uint128]struct{}
+
+	// accountLog and transferLog list accounts and transfers in timestamp
+	// order, for range queries.
+	accountLog  []*tb_types.Account
+	transferLog []*tb_types.Transfer
+	history     map[tb_types.Uint128][]historyEntry
+
+	// journal holds undo operations for the events applied since the last
+	// commit, so a failing linked chain can be rolled back as a whole.
+	journal []func()
+}
+
+// NewMemoryClient returns an empty in-memory ledger.
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{
+		accounts:  make(map[tb_types.Uint128]*tb_types.Account),
+		transfers: make(map[tb_types.Uint128]*tb_types.Transfer),
+		pending:   make(map[tb_types.Uint128]pendingState),
+		failed:    make(map[tb_types.Uint128]struct{}),
+		history:   make(map[tb_types.Uint128][]historyEntry),
+	}
+}
+
+func (c *MemoryClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+func (c *MemoryClient) CreateAccounts(accounts []tb_types.Account) ([]tb_types.AccountEventResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	// A batch is either wholly imported or not at all, as set by its first event
+	imported := len(accounts) > 0 && accounts[0].AccountFlags().Imported
+	codes := applyBatch(c, len(accounts),
+		func(i int) bool { return accounts[i].AccountFlags().Linked },
+		func(i int) tb_types.CreateAccountResult { return c.createAccount(accounts[i], imported) },
+		tb_types.AccountOK, tb_types.AccountLinkedEventFailed, tb_types.AccountLinkedEventChainOpen,
+	)
+
+	var results []tb_types.AccountEventResult
+	for i, code := range codes {
+		if code != tb_types.AccountOK {
+			results = append(results, tb_types.AccountEventResult{Index: uint32(i), Result: code})
+		}
+	}
+	return results, nil
+}
+
+func (c *MemoryClient) CreateTransfers(transfers []tb_types.Transfer) ([]tb_types.TransferEventResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	c.expirePending()
+
+	imported := len(transfers) > 0 && transfers[0].TransferFlags().Imported
+	codes := applyBatch(c, len(transfers),
+		func(i int) bool { return transfers[i].TransferFlags().Linked },
+		func(i int) tb_types.CreateTransferResult { return c.createTransfer(transfers[i], imported) },
+		tb_types.TransferOK, tb_types.TransferLinkedEventFailed, tb_types.TransferLinkedEventChainOpen,
+	)
+
+	var results []tb_types.TransferEventResult
+	for i, code := range codes {
+		if code != tb_types.TransferOK {
+			results = append(results, tb_types.TransferEventResult{Index: uint32(i), Result: code})
+		}
+	}
+	return results, nil
+}
+
+func (c *MemoryClient) LookupAccounts(accountIDs []tb_types.Uint128) ([]tb_types.Account, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	c.expirePending()
+
+	accounts := make([]tb_types.Account, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		if a, ok := c.accounts[id]; ok {
+			accounts = append(accounts, *a)
+		}
+	}
+	return accounts, nil
+}
+
+func (c *MemoryClient) LookupTransfers(transferIDs []tb_types.Uint128) ([]tb_types.Transfer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	transfers := make([]tb_types.Transfer, 0, len(transferIDs))
+	for _, id := range transferIDs {
+		if t, ok := c.transfers[id]; ok {
+			transfers = append(transfers, *t)
+		}
+	}
+	return transfers, nil
+}
+
+func (c *MemoryClient) GetAccountTransfers(filter tb_types.AccountFilter) ([]tb_types.Transfer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	debits := filter.Flags&tb_types.AccountFilterFlags{Debits: true}.ToUint32() != 0
+	credits := filter.Flags&tb_types.AccountFilterFlags{Credits: true}.ToUint32() != 0
+	reversed := filter.Flags&tb_types.AccountFilterFlags{Reversed: true}.ToUint32() != 0
+
+	// The cluster answers invalid filters with an empty result, not an error.
+	if !validFilter(filter) || (!debits && !credits) {
+		return []tb_types.Transfer{}, nil
+	}
+
+	transfers := []tb_types.Transfer{}
+	c.scanTransfers(filter.TimestampMin, filter.TimestampMax, reversed, func(t *tb_types.Transfer) bool {
+		if (debits && t.DebitAccountID == filter.AccountID) || (credits && t.CreditAccountID == filter.AccountID) {
+			transfers = append(transfers, *t)
+		}
+		return len(transfers) < int(filter.Limit)
+	})
+	return transfers, nil
+}
+
+func (c *MemoryClient) GetAccountBalances(filter tb_types.AccountFilter) ([]tb_types.AccountBalance, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	debits := filter.Flags&tb_types.AccountFilterFlags{Debits: true}.ToUint32() != 0
+	credits := filter.Flags&tb_types.AccountFilterFlags{Credits: true}.ToUint32() != 0
+	reversed := filter.Flags&tb_types.AccountFilterFlags{Reversed: true}.ToUint32() != 0
+
+	if !validFilter(filter) || (!debits && !credits) {
+		return []tb_types.AccountBalance{}, nil
+	}
+
+	entries := c.history[filter.AccountID]
+	balances := []tb_types.AccountBalance{}
+	for i := range entries {
+		entry := entries[i]
+		if reversed {
+			entry = entries[len(entries)-1-i]
+		}
+		ts := entry.balance.Timestamp
+		if ts < filter.TimestampMin || (filter.TimestampMax != 0 && ts > filter.TimestampMax) {
+			continue
+		}
+		if (entry.debit && !debits) || (!entry.debit && !credits) {
+			continue
+		}
+		balances = append(balances, entry.balance)
+		if len(balances) == int(filter.Limit) {
+			break
+		}
+	}
+	return balances, nil
+}
+
+func (c *MemoryClient) QueryAccounts(filter tb_types.QueryFilter) ([]tb_types.Account, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	accounts := []tb_types.Account{}
+	if !validQueryFilter(filter) {
+		return accounts, nil
+	}
+
+	reversed := filter.Flags&tb_types.QueryFilterFlags{Reversed: true}.ToUint32() != 0
+	for i := range c.accountLog {
+		a := c.accountLog[i]
+		if reversed {
+			a = c.accountLog[len(c.accountLog)-1-i]
+		}
+		if a.Timestamp < filter.TimestampMin || (filter.TimestampMax != 0 && a.Timestamp > filter.TimestampMax) {
+			continue
+		}
+		if !queryMatches(filter, a.UserData128, a.UserData64, a.UserData32, a.Ledger, a.Code) {
+			continue
+		}
+		accounts = append(accounts, *a)
+		if len(accounts) == int(filter.Limit) {
+			break
+		}
+	}
+	return accounts, nil
+}
+
+func (c *MemoryClient) QueryTransfers(filter tb_types.QueryFilter) ([]tb_types.Transfer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+
+	transfers := []tb_types.Transfer{}
+	if !validQueryFilter(filter) {
+		return transfers, nil
+	}
+
+	reversed := filter.Flags&tb_types.QueryFilterFlags{Reversed: true}.ToUint32() != 0
+	c.scanTransfers(filter.TimestampMin, filter.TimestampMax, reversed, func(t *tb_types.Transfer) bool {
+		if queryMatches(filter, t.UserData128, t.UserData64, t.UserData32, t.Ledger, t.Code) {
+			transfers = append(transfers, *t)
+		}
+		return len(transfers) < int(filter.Limit)
+	})
+	return transfers, nil
+}
+
+func validQueryFilter(filter tb_types.QueryFilter) bool {
+	if filter.Flags&^(tb_types.QueryFilterFlags{Reversed: true}.ToUint32()) != 0 {
+		return false
+	}
+	if filter.TimestampMax != 0 && filter.TimestampMin > filter.TimestampMax {
+		return false
+	}
+	return filter.Limit != 0
+}
+
+// queryMatches reports whether an object has every non-zero field of the
+// filter; zero fields match anything.
+func queryMatches(filter tb_types.QueryFilter, userData128 tb_types.Uint128, userData64 uint64, userData32 uint32, ledger uint32, code uint16) bool {
+	return (validation.IsZeroID(filter.UserData128) || filter.UserData128 == userData128) &&
+		(filter.UserData64 == 0 || filter.UserData64 == userData64) &&
+		(filter.UserData32 == 0 || filter.UserData32 == userData32) &&
+		(filter.Ledger == 0 || filter.Ledger == ledger) &&
+		(filter.Code == 0 || filter.Code == code)
+}
+
+func validFilter(filter tb_types.AccountFilter) bool {
+	if validation.IsZeroID(filter.AccountID) || filter.AccountID == tbutil.MaxUint128 {
+		return false
+	}
+	if filter.TimestampMax != 0 && filter.TimestampMin > filter.TimestampMax {
+		return false
+	}
+	return filter.Limit != 0
+}
+
+// scanTransfers visits transfers with timestamps in [tsMin, tsMax], where zero
+// means unbounded, in timestamp order or reversed. It stops when visit
+// returns false.
+func (c *MemoryClient) scanTransfers(tsMin, tsMax uint64, reversed bool, visit func(*tb_types.Transfer) bool) {
+	for i := range c.transferLog {
+		t := c.transferLog[i]
+		if reversed {
+			t = c.transferLog[len(c.transferLog)-1-i]
+		}
+		if t.Timestamp < tsMin || (tsMax != 0 && t.Timestamp > tsMax) {
+			continue
+		}
+		if !visit(t) {
+			return
+		}
+	}
+}
+
+// applyBatch applies n events in order. Events flagged as linked form a chain
+// with the event that follows them: if any event in a chain fails, every
+// change made by the chain is rolled back and the remaining events of the
+// chain fail with linkedFailed. The last event of a chain left open at the
+// end of the batch fails with chainOpen, even when an earlier event of the
+// chain already failed.
+func applyBatch[R comparable](c *MemoryClient, n int, linked func(int) bool, apply func(int) R, ok, linkedFailed, chainOpen R) []R {
+	results := make([]R, n)
+	for i := range results {
+		results[i] = ok
+	}
+
+	chainStart := -1
+	for i := 0; i < n; i++ {
+		if linked(i) && chainStart < 0 {
+			chainStart = i
+		}
+
+		var result R
+		if linked(i) && i == n-1 {
+			result = chainOpen
+		} else {
+			result = apply(i)
+		}
+
+		if result == ok {
+			if !linked(i) {
+				c.commit()
+				chainStart = -1
+			}
+			continue
+		}
+
+		results[i] = result
+		if chainStart < 0 {
+			// A failed event changes nothing but the failed IDs it leaves.
+			c.commit()
+			continue
+		}
+		c.rollback()
+		for j := chainStart; j < i; j++ {
+			results[j] = linkedFailed
+		}
+		for linked(i) && i+1 < n {
+			i++
+			results[i] = linkedFailed
+		}
+		if linked(i) && i == n-1 {
+			// The chain is still open at the end of the batch.
+			results[i] = chainOpen
+		}
+		chainStart = -1
+	}
+	c.commit()
+	return results
+}
+
+func (c *MemoryClient) record(undo func()) {
+	c.journal = append(c.journal, undo)
+}
+
+func (c *MemoryClient) commit() {
+	c.journal = c.journal[:0]
+}
+
+func (c *MemoryClient) rollback() {
+	for i := len(c.journal) - 1; i >= 0; i-- {
+		c.journal[i]()
+	}
+	c.journal = c.journal[:0]
+}
+
+// saveAccount records the current state of an account so it can be restored
+// on rollback. It must be called before the account is mutated.
+func (c *MemoryClient) saveAccount(a *tb_types.Account) {
+	saved := *a
+	c.record(func() { *a = saved })
+}
+
+func (c *MemoryClient) setPending(id tb_types.Uint128, state pendingState) {
+	previous, existed := c.pending[id]
+	c.pending[id] = state
+	c.record(func() {
+		if existed {
+			c.pending[id] = previous
+		} else {
+			delete(c.pending, id)
+		}
+	})
+}
+
+func (c *MemoryClient) storeTransfer(t tb_types.Transfer) {
+	c.transfers[t.ID] = &t
+	c.transferLog = append(c.transferLog, &t)
+	c.record(func() {
+		delete(c.transfers, t.ID)
+		c.transferLog = c.transferLog[:len(c.transferLog)-1]
+	})
+
+	c.recordHistory(c.accounts[t.DebitAccountID], t.Timestamp, true)
+	c.recordHistory(c.accounts[t.CreditAccountID], t.Timestamp, false)
+}
+
+// recordHistory snapshots the balances of an account with the History flag
+// right after a transfer touched it.
+func (c *MemoryClient) recordHistory(a *tb_types.Account, timestamp uint64, debit bool) {
+	if !a.AccountFlags().History {
+		return
+	}
+
+	c.history[a.ID] = append(c.history[a.ID], historyEntry{
+		balance: tb_types.AccountBalance{
+			DebitsPending:  a.DebitsPending,
+			DebitsPosted:   a.DebitsPosted,
+			CreditsPending: a.CreditsPending,
+			CreditsPosted:  a.CreditsPosted,
+			Timestamp:      timestamp,
+		},
+		debit: debit,
+	})
+	c.record(func() { c.history[a.ID] = c.history[a.ID][:len(c.history[a.ID])-1] })
+}
+
+// now returns the current cluster time, which imported events must precede.
+func (c *MemoryClient) now() uint64 {
+	return max(uint64(time.Now().UnixNano()), c.timestamp+1)
+}
+
+// tick returns a strictly increasing cluster timestamp in nanoseconds.
+func (c *MemoryClient) tick() uint64 {
+	now := uint64(time.Now().UnixNano())
+	if now <= c.timestamp {
+		now = c.timestamp + 1
+	}
+	c.timestamp = now
+	return now
+}
+
+func (c *MemoryClient) createAccount(a tb_types.Account, importedBatch bool) tb_types.CreateAccountResult {
+	flags := a.AccountFlags()
+
+	if flags.Imported != importedBatch {
+		if importedBatch {
+			return tb_types.AccountImportedEventExpected
+		}
+		return tb_types.AccountImportedEventNotExpected
+	}
+	if flags.Imported {
+		if a.Timestamp == 0 || a.Timestamp > maxTimestamp {
+			return tb_types.AccountImportedEventTimestampOutOfRange
+		}
+		if a.Timestamp >= c.now() {
+			return tb_types.AccountImportedEventTimestampMustNotAdvance
+		}
+	} else if a.Timestamp != 0 {
+		return tb_types.AccountTimestampMustBeZero
+	}
+	if a.Reserved != 0 {
+		return tb_types.AccountReservedField
+	}
+	if a.Flags&^accountFlagsMask != 0 {
+		return tb_types.AccountReservedFlag
+	}
+	if validation.IsZeroID(a.ID) {
+		return tb_types.AccountIDMustNotBeZero
+	}
+	if a.ID == tbutil.MaxUint128 {
+		return tb_types.AccountIDMustNotBeIntMax
+	}
+	if e, ok := c.accounts[a.ID]; ok {
+		return accountExists(a, *e)
+	}
+	if flags.DebitsMustNotExceedCredits && flags.CreditsMustNotExceedDebits {
+		return tb_types.AccountFlagsAreMutuallyExclusive
+	}
+	if !validation.IsZeroID(a.DebitsPending) {
+		return tb_types.AccountDebitsPendingMustBeZero
+	}
+	if !validation.IsZeroID(a.DebitsPosted) {
+		return tb_types.AccountDebitsPostedMustBeZero
+	}
+	if !validation.IsZeroID(a.CreditsPending) {
+		return tb_types.AccountCreditsPendingMustBeZero
+	}
+	if !validation.IsZeroID(a.CreditsPosted) {
+		return tb_types.AccountCreditsPostedMustBeZero
+	}
+	if a.Ledger == 0 {
+		return tb_types.AccountLedgerMustNotBeZero
+	}
+	if a.Code == 0 {
+		return tb_types.AccountCodeMustNotBeZero
+	}
+	if flags.Imported && len(c.accountLog) > 0 && a.Timestamp <= c.accountLog[len(c.accountLog)-1].Timestamp {
+		return tb_types.AccountImportedEventTimestampMustNotRegress
+	}
+
+	if !flags.Imported {
+		a.Timestamp = c.tick()
+	}
+	c.accounts[a.ID] = &a
+	c.accountLog = append(c.accountLog, &a)
+	c.record(func() {
+		delete(c.accounts, a.ID)
+		c.accountLog = c.accountLog[:len(c.accountLog)-1]
+	})
+	return tb_types.AccountOK
+}
+
+func accountExists(a, e tb_types.Account) tb_types.CreateAccountResult {
+	switch {
+	case a.Flags != e.Flags:
+		return tb_types.AccountExistsWithDifferentFlags
+	case a.UserData128 != e.UserData128:
+		return tb_types.AccountExistsWithDifferentUserData128
+	case a.UserData64 != e.UserData64:
+		return tb_types.AccountExistsWithDifferentUserData64
+	case a.UserData32 != e.UserData32:
+		return tb_types.AccountExistsWithDifferentUserData32
+	case a.Ledger != e.Ledger:
+		return tb_types.AccountExistsWithDifferentLedger
+	case a.Code != e.Code:
+		return tb_types.AccountExistsWithDifferentCode
+	}
+	return tb_types.AccountExists
+}
+
+func (c *MemoryClient) createTransfer(t tb_types.Transfer, importedBatch bool) tb_types.CreateTransferResult {
+	result := c.validateAndApplyTransfer(t, importedBatch)
+	if isTransientTransferResult(result) {
+		c.markFailed(t.ID)
+	}
+	return result
+}
+
+// markFailed remembers a transfer ID that failed for a transient reason.
+// Like the rest of the state it is journaled, so a chain that is rolled
+// back leaves its IDs free to be retried.
+func (c *MemoryClient) markFailed(id tb_types.Uint128) {
+	c.failed[id] = struct{}{}
+	c.record(func() { delete(c.failed, id) })
+}
+
+func (c *MemoryClient) validateAndApplyTransfer(t tb_types.Transfer, importedBatch bool) tb_types.CreateTransferResult {
+	flags := t.TransferFlags()
+
+	if flags.Imported != importedBatch {
+		if importedBatch {
+			return tb_types.TransferImportedEventExpected
+		}
+		return tb_types.TransferImportedEventNotExpected
+	}
+	if flags.Imported {
+		if t.Timestamp == 0 || t.Timestamp > maxTimestamp {
+			return tb_types.TransferImportedEventTimestampOutOfRange
+		}
+		if t.Timestamp >= c.now() {
+			return tb_types.TransferImportedEventTimestampMustNotAdvance
+		}
+	} else if t.Timestamp != 0 {
+		return tb_types.TransferTimestampMustBeZero
+	}
+	if t.Flags&^transferFlagsMask != 0 {
+		return tb_types.TransferReservedFlag
+	}
+	if validation.IsZeroID(t.ID) {
+		return tb_types.TransferIDMustNotBeZero
+	}
+	if t.ID == tbutil.MaxUint128 {
+		return tb_types.TransferIDMustNotBeIntMax
+	}
+	if e, ok := c.transfers[t.ID]; ok {
+		return transferExists(t, *e)
+	}
+	if _, ok := c.failed[t.ID]; ok {
+		return tb_types.TransferIDAlreadyFailed
+	}
+
+	if flags.PostPendingTransfer || flags.VoidPendingTransfer {
+		return c.postOrVoidPendingTransfer(t)
+	}
+
+	if validation.IsZeroID(t.DebitAccountID) {
+		return tb_types.TransferDebitAccountIDMustNotBeZero
+	}
+	if t.DebitAccountID == tbutil.MaxUint128 {
+		return tb_types.TransferDebitAccountIDMustNotBeIntMax
+	}
+	if validation.IsZeroID(t.CreditAccountID) {
+		return tb_types.TransferCreditAccountIDMustNotBeZero
+	}
+	if t.CreditAccountID == tbutil.MaxUint128 {
+		return tb_types.TransferCreditAccountIDMustNotBeIntMax
+	}
+	if t.DebitAccountID == t.CreditAccountID {
+		return tb_types.TransferAccountsMustBeDifferent
+	}
+	if !validation.IsZeroID(t.PendingID) {
+		return tb_types.TransferPendingIDMustBeZero
+	}
+	if !flags.Pending && t.Timeout != 0 {
+		return tb_types.TransferTimeoutReservedForPendingTransfer
+	}
+	if !flags.Pending && (flags.ClosingDebit || flags.ClosingCredit) {
+		return tb_types.TransferClosingTransferMustBePending
+	}
+	if flags.Imported && t.Timeout != 0 {
+		return tb_types.TransferImportedEventTimeoutMustBeZero
+	}
+	if t.Ledger == 0 {
+		return tb_types.TransferLedgerMustNotBeZero
+	}
+	if t.Code == 0 {
+		return tb_types.TransferCodeMustNotBeZero
+	}
+
+	dr, ok := c.accounts[t.DebitAccountID]
+	if !ok {
+		return tb_types.TransferDebitAccountNotFound
+	}
+	cr, ok := c.accounts[t.CreditAccountID]
+	if !ok {
+		return tb_types.TransferCreditAccountNotFound
+	}
+	if dr.Ledger != cr.Ledger {
+		return tb_types.TransferAccountsMustHaveTheSameLedger
+	}
+	if t.Ledger != dr.Ledger {
+		return tb_types.TransferTransferMustHaveTheSameLedgerAsAccounts
+	}
+	if dr.AccountFlags().Closed {
+		return tb_types.TransferDebitAccountAlreadyClosed
+	}
+	if cr.AccountFlags().Closed {
+		return tb_types.TransferCreditAccountAlreadyClosed
+	}
+	if flags.Imported {
+		if result := c.checkImportedTransferTimestamp(t.Timestamp); result != tb_types.TransferOK {
+			return result
+		}
+		if t.Timestamp <= dr.Timestamp {
+			return tb_types.TransferImportedEventTimestampMustPostdateDebitAccount
+		}
+		if t.Timestamp <= cr.Timestamp {
+			return tb_types.TransferImportedEventTimestampMustPostdateCreditAccount
+		}
+	}
+
+	amount := t.Amount
+	if flags.BalancingDebit {
+		amount = tbutil.MinUint128(amount, available(dr.CreditsPosted, dr.DebitsPosted, dr.DebitsPending))
+	}
+	if flags.BalancingCredit {
+		amount = tbutil.MinUint128(amount, available(cr.DebitsPosted, cr.CreditsPosted, cr.CreditsPending))
+	}
+
+	if flags.Pending {
+		if _, overflow := tbutil.AddUint128(dr.DebitsPending, amount); overflow {
+			return tb_types.TransferOverflowsDebitsPending
+		}
+		if _, overflow := tbutil.AddUint128(cr.CreditsPending, amount); overflow {
+			return tb_types.TransferOverflowsCreditsPending
+		}
+	} else {
+		if _, overflow := tbutil.AddUint128(dr.DebitsPosted, amount); overflow {
+			return tb_types.TransferOverflowsDebitsPosted
+		}
+		if _, overflow := tbutil.AddUint128(cr.CreditsPosted, amount); overflow {
+			return tb_types.TransferOverflowsCreditsPosted
+		}
+	}
+
+	debits, overflow := sumUint128(dr.DebitsPending, dr.DebitsPosted, amount)
+	if overflow {
+		return tb_types.TransferOverflowsDebits
+	}
+	credits, overflow := sumUint128(cr.CreditsPending, cr.CreditsPosted, amount)
+	if overflow {
+		return tb_types.TransferOverflowsCredits
+	}
+	if dr.AccountFlags().DebitsMustNotExceedCredits && tbutil.CompareUint128(debits, dr.CreditsPosted) > 0 {
+		return tb_types.TransferExceedsCredits
+	}
+	if cr.AccountFlags().CreditsMustNotExceedDebits && tbutil.CompareUint128(credits, cr.DebitsPosted) > 0 {
+		return tb_types.TransferExceedsDebits
+	}
+
+	c.saveAccount(dr)
+	c.saveAccount(cr)
+	if flags.Pending {
+		dr.DebitsPending, _ = tbutil.AddUint128(dr.DebitsPending, amount)
+		cr.CreditsPending, _ = tbutil.AddUint128(cr.CreditsPending, amount)
+		if flags.ClosingDebit {
+			dr.Flags |= accountClosedFlag
+		}
+		if flags.ClosingCredit {
+			cr.Flags |= accountClosedFlag
+		}
+	} else {
+		dr.DebitsPosted, _ = tbutil.AddUint128(dr.DebitsPosted, amount)
+		cr.CreditsPosted, _ = tbutil.AddUint128(cr.CreditsPosted, amount)
+	}
+
+	t.Amount = amount
+	if !flags.Imported {
+		t.Timestamp = c.tick()
+	}
+	c.storeTransfer(t)
+	if flags.Pending {
+		c.setPending(t.ID, pendingActive)
+	}
+	return tb_types.TransferOK
+}
+
+func (c *MemoryClient) postOrVoidPendingTransfer(t tb_types.Transfer) tb_types.CreateTransferResult {
+	flags := t.TransferFlags()
+
+	if flags.PostPendingTransfer && flags.VoidPendingTransfer {
+		return tb_types.TransferFlagsAreMutuallyExclusive
+	}
+	if flags.Pending || flags.BalancingDebit || flags.BalancingCredit || flags.ClosingDebit || flags.ClosingCredit {
+		return tb_types.TransferFlagsAreMutuallyExclusive
+	}
+	if validation.IsZeroID(t.PendingID) {
+		return tb_types.TransferPendingIDMustNotBeZero
+	}
+	if t.PendingID == tbutil.MaxUint128 {
+		return tb_types.TransferPendingIDMustNotBeIntMax
+	}
+	if t.PendingID == t.ID {
+		return tb_types.TransferPendingIDMustBeDifferent
+	}
+	if t.Timeout != 0 {
+		return tb_types.TransferTimeoutReservedForPendingTransfer
+	}
+
+	p, ok := c.transfers[t.PendingID]
+	if !ok {
+		return tb_types.TransferPendingTransferNotFound
+	}
+	if !p.TransferFlags().Pending {
+		return tb_types.TransferPendingTransferNotPending
+	}
+	if !validation.IsZeroID(t.DebitAccountID) && t.DebitAccountID != p.DebitAccountID {
+		return tb_types.TransferPendingTransferHasDifferentDebitAccountID
+	}
+	if !validation.IsZeroID(t.CreditAccountID) && t.CreditAccountID != p.CreditAccountID {
+		return tb_types.TransferPendingTransferHasDifferentCreditAccountID
+	}
+	if t.Ledger != 0 && t.Ledger != p.Ledger {
+		return tb_types.TransferPendingTransferHasDifferentLedger
+	}
+	if t.Code != 0 && t.Code != p.Code {
+		return tb_types.TransferPendingTransferHasDifferentCode
+	}
+
+	amount := t.Amount
+	if flags.PostPendingTransfer {
+		if amount == tbutil.MaxUint128 {
+			amount = p.Amount
+		} else if tbutil.CompareUint128(amount, p.Amount) > 0 {
+			return tb_types.TransferExceedsPendingTransferAmount
+		}
+	} else {
+		if validation.IsZeroID(amount) || amount == tbutil.MaxUint128 {
+			amount = p.Amount
+		} else if amount != p.Amount {
+			return tb_types.TransferPendingTransferHasDifferentAmount
+		}
+	}
+
+	switch c.pending[p.ID] {
+	case pendingPosted:
+		return tb_types.TransferPendingTransferAlreadyPosted
+	case pendingVoided:
+		return tb_types.TransferPendingTransferAlreadyVoided
+	case pendingExpired:
+		return tb_types.TransferPendingTransferExpired
+	}
+	if flags.Imported {
+		if result := c.checkImportedTransferTimestamp(t.Timestamp); result != tb_types.TransferOK {
+			return result
+		}
+	}
+
+	dr := c.accounts[p.DebitAccountID]
+	cr := c.accounts[p.CreditAccountID]
+
+	c.saveAccount(dr)
+	c.saveAccount(cr)
+	releasePending(dr, cr, *p)
+	if flags.PostPendingTransfer {
+		dr.DebitsPosted, _ = tbutil.AddUint128(dr.DebitsPosted, amount)
+		cr.CreditsPosted, _ = tbutil.AddUint128(cr.CreditsPosted, amount)
+		c.setPending(p.ID, pendingPosted)
+	} else {
+		c.setPending(p.ID, pendingVoided)
+	}
+
+	t.DebitAccountID = p.DebitAccountID
+	t.CreditAccountID = p.CreditAccountID
+	t.Ledger = p.Ledger
+	t.Code = p.Code
+	t.Amount = amount
+	if !flags.Imported {
+		t.Timestamp = c.tick()
+	}
+	c.storeTransfer(t)
+	return tb_types.TransferOK
+}
+
+// checkImportedTransferTimestamp requires an imported transfer to be newer
+// than every transfer already stored.
+func (c *MemoryClient) checkImportedTransferTimestamp(timestamp uint64) tb_types.CreateTransferResult {
+	if len(c.transferLog) > 0 && timestamp <= c.transferLog[len(c.transferLog)-1].Timestamp {
+		return tb_types.TransferImportedEventTimestampMustNotRegress
+	}
+	return tb_types.TransferOK
+}
+
+// expirePending releases the amounts held by pending transfers whose timeout
+// has elapsed, as the cluster does when it expires them.
+func (c *MemoryClient) expirePending() {
+	now := uint64(time.Now().UnixNano())
+	for id, state := range c.pending {
+		if state != pendingActive {
+			continue
+		}
+		p := c.transfers[id]
+		if p.Timeout == 0 || p.Timestamp+uint64(p.Timeout)*uint64(time.Second) > now {
+			continue
+		}
+		releasePending(c.accounts[p.DebitAccountID], c.accounts[p.CreditAccountID], *p)
+		c.pending[id] = pendingExpired
+	}
+}
+
+// releasePending removes a pending transfer's amount from the pending
+// balances and reopens any account it closed.
+func releasePending(dr, cr *tb_types.Account, p tb_types.Transfer) {
+	flags := p.TransferFlags()
+	dr.DebitsPending, _ = tbutil.SubUint128(dr.DebitsPending, p.Amount)
+	cr.CreditsPending, _ = tbutil.SubUint128(cr.CreditsPending, p.Amount)
+	if flags.ClosingDebit {
+		dr.Flags &^= accountClosedFlag
+	}
+	if flags.ClosingCredit {
+		cr.Flags &^= accountClosedFlag
+	}
+}
+
+func transferExists(t, e tb_types.Transfer) tb_types.CreateTransferResult {
+	flags := t.TransferFlags()
+	postOrVoid := flags.PostPendingTransfer || flags.VoidPendingTransfer
+
+	if t.Flags != e.Flags {
+		return tb_types.TransferExistsWithDifferentFlags
+	}
+	if postOrVoid {
+		if t.PendingID != e.PendingID {
+			return tb_types.TransferExistsWithDifferentPendingID
+		}
+	} else {
+		if t.DebitAccountID != e.DebitAccountID {
+			return tb_types.TransferExistsWithDifferentDebitAccountID
+		}
+		if t.CreditAccountID != e.CreditAccountID {
+			return tb_types.TransferExistsWithDifferentCreditAccountID
+		}
+	}
+
+	// Balancing and post/void transfers may store less than the requested
+	// amount, so only a smaller stored amount is a mismatch for them.
+	switch {
+	case flags.BalancingDebit || flags.BalancingCredit || postOrVoid:
+		if t.Amount != tbutil.MaxUint128 && !validation.IsZeroID(t.Amount) && tbutil.CompareUint128(t.Amount, e.Amount) < 0 {
+			return tb_types.TransferExistsWithDifferentAmount
+		}
+	case t.Amount != e.Amount:
+		return tb_types.TransferExistsWithDifferentAmount
+	}
+
+	switch {
+	case t.UserData128 != e.UserData128:
+		return tb_types.TransferExistsWithDifferentUserData128
+	case t.UserData64 != e.UserData64:
+		return tb_types.TransferExistsWithDifferentUserData64
+	case t.UserData32 != e.UserData32:
+		return tb_types.TransferExistsWithDifferentUserData32
+	case t.Timeout != e.Timeout:
+		return tb_types.TransferExistsWithDifferentTimeout
+	case (!postOrVoid || t.Ledger != 0) && t.Ledger != e.Ledger:
+		return tb_types.TransferExistsWithDifferentLedger
+	case (!postOrVoid || t.Code != 0) && t.Code != e.Code:
+		return tb_types.TransferExistsWithDifferentCode
+	}
+	return tb_types.TransferExists
+}
+
+// isTransientTransferResult reports whether a failure depends on ledger state
+// rather than on the event itself. TigerBeetle remembers such IDs and rejects
+// retries with id_already_failed.
+func isTransientTransferResult(result tb_types.CreateTransferResult) bool {
+	switch result {
+	case tb_types.TransferDebitAccountNotFound,
+		tb_types.TransferCreditAccountNotFound,
+		tb_types.TransferPendingTransferNotFound,
+		tb_types.TransferExceedsCredits,
+		tb_types.TransferExceedsDebits,
+		tb_types.TransferDebitAccountAlreadyClosed,
+		tb_types.TransferCreditAccountAlreadyClosed:
+		return true
+	}
+	return false
+}
+
+// available returns limit - (used + reserved), saturating at zero.
+func available(limit, used, reserved tb_types.Uint128) tb_types.Uint128 {
+	total, overflow := tbutil.AddUint128(used, reserved)
+	if overflow {
+		return tb_types.Uint128{}
+	}
+	diff, underflow := tbutil.SubUint128(limit, total)
+	if underflow {
+		return tb_types.Uint128{}
+	}
+	return diff
+}
+
+func sumUint128(values ...tb_types.Uint128) (tb_types.Uint128, bool) {
+	var sum tb_types.Uint128
+	for _, v := range values {
+		var overflow bool
+		sum, overflow = tbutil.AddUint128(sum, v)
+		if overflow {
+			return sum, true
+		}
+	}
+	return sum, false
+}
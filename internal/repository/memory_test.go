@@ -0,0 +1,417 @@
+package repository_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newAccount(id uint64, ledger uint32, flags tb_types.AccountFlags) tb_types.Account {
+	return tb_types.Account{
+		ID:          tb_types.ToUint128(id),
+		UserData128: tb_types.ToUint128(1),
+		Ledger:      ledger,
+		Code:        1,
+		Flags:       flags.ToUint16(),
+	}
+}
+
+func newTransfer(id, debit, credit, amount uint64, flags tb_types.TransferFlags) tb_types.Transfer {
+	return tb_types.Transfer{
+		ID:              tb_types.ToUint128(id),
+		DebitAccountID:  tb_types.ToUint128(debit),
+		CreditAccountID: tb_types.ToUint128(credit),
+		Amount:          tb_types.ToUint128(amount),
+		Ledger:          1,
+		Code:            1,
+		Flags:           flags.ToUint16(),
+	}
+}
+
+func lookupAccount(t *testing.T, c *repository.MemoryClient, id uint64) tb_types.Account {
+	t.Helper()
+	accounts, err := c.LookupAccounts([]tb_types.Uint128{tb_types.ToUint128(id)})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	return accounts[0]
+}
+
+func TestMemoryClientCreateAccounts(t *testing.T) {
+	c := repository.NewMemoryClient()
+
+	results, err := c.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(1, 2, tb_types.AccountFlags{}),
+		newAccount(2, 0, tb_types.AccountFlags{}),
+		newAccount(3, 1, tb_types.AccountFlags{DebitsMustNotExceedCredits: true, CreditsMustNotExceedDebits: true}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.AccountEventResult{
+		{Index: 1, Result: tb_types.AccountExists},
+		{Index: 2, Result: tb_types.AccountExistsWithDifferentLedger},
+		{Index: 3, Result: tb_types.AccountLedgerMustNotBeZero},
+		{Index: 4, Result: tb_types.AccountFlagsAreMutuallyExclusive},
+	}, results)
+
+	account := lookupAccount(t, c, 1)
+	assert.NotZero(t, account.Timestamp)
+}
+
+func TestMemoryClientCreateTransfers(t *testing.T) {
+	c := repository.NewMemoryClient()
+	_, err := c.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{DebitsMustNotExceedCredits: true}),
+		newAccount(2, 1, tb_types.AccountFlags{}),
+		newAccount(3, 2, tb_types.AccountFlags{}),
+	})
+	require.NoError(t, err)
+
+	results, err := c.CreateTransfers([]tb_types.Transfer{
+		newTransfer(10, 2, 1, 100, tb_types.TransferFlags{}),
+		newTransfer(11, 1, 2, 150, tb_types.TransferFlags{}),
+		newTransfer(12, 1, 3, 10, tb_types.TransferFlags{}),
+		newTransfer(13, 1, 9, 10, tb_types.TransferFlags{}),
+		newTransfer(10, 2, 1, 100, tb_types.TransferFlags{}),
+		newTransfer(10, 2, 1, 99, tb_types.TransferFlags{}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.TransferEventResult{
+		{Index: 1, Result: tb_types.TransferExceedsCredits},
+		{Index: 2, Result: tb_types.TransferAccountsMustHaveTheSameLedger},
+		{Index: 3, Result: tb_types.TransferCreditAccountNotFound},
+		{Index: 4, Result: tb_types.TransferExists},
+		{Index: 5, Result: tb_types.TransferExistsWithDifferentAmount},
+	}, results)
+
+	// Transient failures are remembered by ID.
+	results, err = c.CreateTransfers([]tb_types.Transfer{newTransfer(11, 1, 2, 50, tb_types.TransferFlags{})})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.TransferEventResult{{Index: 0, Result: tb_types.TransferIDAlreadyFailed}}, results)
+
+	account := lookupAccount(t, c, 1)
+	assert.Equal(t, tb_types.ToUint128(100), account.CreditsPosted)
+	assert.Equal(t, tb_types.Uint128{}, account.DebitsPosted)
+}
+
+func TestMemoryClientPendingTransfers(t *testing.T) {
+	c := repository.NewMemoryClient()
+	_, err := c.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(2, 1, tb_types.AccountFlags{}),
+	})
+	require.NoError(t, err)
+
+	results, err := c.CreateTransfers([]tb_types.Transfer{
+		newTransfer(10, 1, 2, 100, tb_types.TransferFlags{Pending: true}),
+		newTransfer(11, 1, 2, 40, tb_types.TransferFlags{Pending: true}),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	account := lookupAccount(t, c, 1)
+	assert.Equal(t, tb_types.ToUint128(140), account.DebitsPending)
+
+	post := tb_types.Transfer{
+		ID:        tb_types.ToUint128(20),
+		PendingID: tb_types.ToUint128(10),
+		Amount:    tb_types.ToUint128(60),
+		Flags:     tb_types.TransferFlags{PostPendingTransfer: true}.ToUint16(),
+	}
+	void := tb_types.Transfer{
+		ID:        tb_types.ToUint128(21),
+		PendingID: tb_types.ToUint128(11),
+		Flags:     tb_types.TransferFlags{VoidPendingTransfer: true}.ToUint16(),
+	}
+	tooMuch := post
+	tooMuch.ID = tb_types.ToUint128(22)
+	tooMuch.PendingID = tb_types.ToUint128(11)
+	tooMuch.Amount = tb_types.ToUint128(41)
+	again := void
+	again.ID = tb_types.ToUint128(23)
+
+	results, err = c.CreateTransfers([]tb_types.Transfer{post, void, tooMuch, again})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.TransferEventResult{
+		{Index: 2, Result: tb_types.TransferExceedsPendingTransferAmount},
+		{Index: 3, Result: tb_types.TransferPendingTransferAlreadyVoided},
+	}, results)
+
+	account = lookupAccount(t, c, 1)
+	assert.Equal(t, tb_types.Uint128{}, account.DebitsPending)
+	assert.Equal(t, tb_types.ToUint128(60), account.DebitsPosted)
+
+	full := tb_types.Transfer{
+		ID:        tb_types.ToUint128(30),
+		PendingID: tb_types.ToUint128(10),
+		Amount:    tbutil.MaxUint128,
+		Flags:     tb_types.TransferFlags{PostPendingTransfer: true}.ToUint16(),
+	}
+	results, err = c.CreateTransfers([]tb_types.Transfer{full})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.TransferEventResult{{Index: 0, Result: tb_types.TransferPendingTransferAlreadyPosted}}, results)
+}
+
+func TestMemoryClientLinkedChainRollsBack(t *testing.T) {
+	c := repository.NewMemoryClient()
+	_, err := c.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(2, 1, tb_types.AccountFlags{}),
+	})
+	require.NoError(t, err)
+
+	linked := tb_types.TransferFlags{Linked: true}
+	results, err := c.CreateTransfers([]tb_types.Transfer{
+		newTransfer(10, 1, 2, 10, linked),
+		newTransfer(11, 1, 9, 10, linked),
+		newTransfer(12, 1, 2, 10, tb_types.TransferFlags{}),
+		newTransfer(13, 1, 2, 10, linked),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.TransferEventResult{
+		{Index: 0, Result: tb_types.TransferLinkedEventFailed},
+		{Index: 1, Result: tb_types.TransferCreditAccountNotFound},
+		{Index: 2, Result: tb_types.TransferLinkedEventFailed},
+		{Index: 3, Result: tb_types.TransferLinkedEventChainOpen},
+	}, results)
+
+	account := lookupAccount(t, c, 1)
+	assert.Equal(t, tb_types.Uint128{}, account.DebitsPosted)
+
+	transfers, err := c.LookupTransfers([]tb_types.Uint128{tb_types.ToUint128(10), tb_types.ToUint128(12)})
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+}
+
+func TestMemoryClientFailedChainLeftOpen(t *testing.T) {
+	c := repository.NewMemoryClient()
+	_, err := c.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(2, 1, tb_types.AccountFlags{}),
+	})
+	require.NoError(t, err)
+
+	linked := tb_types.TransferFlags{Linked: true}
+	results, err := c.CreateTransfers([]tb_types.Transfer{
+		newTransfer(10, 1, 2, 10, linked),
+		newTransfer(11, 1, 9, 10, linked),
+		newTransfer(12, 1, 2, 10, linked),
+		newTransfer(13, 1, 2, 10, linked),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.TransferEventResult{
+		{Index: 0, Result: tb_types.TransferLinkedEventFailed},
+		{Index: 1, Result: tb_types.TransferCreditAccountNotFound},
+		{Index: 2, Result: tb_types.TransferLinkedEventFailed},
+		{Index: 3, Result: tb_types.TransferLinkedEventChainOpen},
+	}, results)
+
+	account := lookupAccount(t, c, 1)
+	assert.Equal(t, tb_types.Uint128{}, account.DebitsPosted)
+}
+
+func TestMemoryClientRetryRolledBackChain(t *testing.T) {
+	c := repository.NewMemoryClient()
+	_, err := c.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(2, 1, tb_types.AccountFlags{}),
+	})
+	require.NoError(t, err)
+
+	linked := tb_types.TransferFlags{Linked: true}
+	results, err := c.CreateTransfers([]tb_types.Transfer{
+		newTransfer(10, 1, 2, 10, linked),
+		newTransfer(11, 1, 3, 10, tb_types.TransferFlags{}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.TransferEventResult{
+		{Index: 0, Result: tb_types.TransferLinkedEventFailed},
+		{Index: 1, Result: tb_types.TransferCreditAccountNotFound},
+	}, results)
+
+	// Once the missing account exists, the same chain goes through.
+	_, err = c.CreateAccounts([]tb_types.Account{newAccount(3, 1, tb_types.AccountFlags{})})
+	require.NoError(t, err)
+	results, err = c.CreateTransfers([]tb_types.Transfer{
+		newTransfer(10, 1, 2, 10, linked),
+		newTransfer(11, 1, 3, 10, tb_types.TransferFlags{}),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	account := lookupAccount(t, c, 1)
+	assert.Equal(t, tb_types.ToUint128(20), account.DebitsPosted)
+}
+
+func TestMemoryClientQuery(t *testing.T) {
+	c := repository.NewMemoryClient()
+	customer := newAccount(3, 1, tb_types.AccountFlags{})
+	customer.UserData128 = tb_types.ToUint128(7)
+	_, err := c.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(2, 2, tb_types.AccountFlags{}),
+		customer,
+		// rolled back with its chain, so never matched
+		newAccount(4, 1, tb_types.AccountFlags{Linked: true}),
+		newAccount(4, 1, tb_types.AccountFlags{}),
+	})
+	require.NoError(t, err)
+
+	ids := func(accounts []tb_types.Account) []tb_types.Uint128 {
+		var ids []tb_types.Uint128
+		for _, a := range accounts {
+			ids = append(ids, a.ID)
+		}
+		return ids
+	}
+
+	accounts, err := c.QueryAccounts(tb_types.QueryFilter{Ledger: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.Uint128{tb_types.ToUint128(1), tb_types.ToUint128(3)}, ids(accounts))
+
+	accounts, err = c.QueryAccounts(tb_types.QueryFilter{Ledger: 1, Limit: 10, Flags: tb_types.QueryFilterFlags{Reversed: true}.ToUint32()})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.Uint128{tb_types.ToUint128(3), tb_types.ToUint128(1)}, ids(accounts))
+
+	accounts, err = c.QueryAccounts(tb_types.QueryFilter{UserData128: tb_types.ToUint128(7), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []tb_types.Uint128{tb_types.ToUint128(3)}, ids(accounts))
+
+	accounts, err = c.QueryAccounts(tb_types.QueryFilter{Ledger: 1})
+	require.NoError(t, err)
+	assert.Empty(t, accounts, "a zero limit matches nothing")
+
+	refund := newTransfer(11, 1, 3, 5, tb_types.TransferFlags{})
+	refund.Code = 7
+	_, err = c.CreateTransfers([]tb_types.Transfer{newTransfer(10, 1, 3, 5, tb_types.TransferFlags{}), refund})
+	require.NoError(t, err)
+
+	transfers, err := c.QueryTransfers(tb_types.QueryFilter{Ledger: 1, Code: 7, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, refund.ID, transfers[0].ID)
+
+	transfers, err = c.QueryTransfers(tb_types.QueryFilter{TimestampMin: transfers[0].Timestamp + 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, transfers)
+}
+
+// blockingClient simulates an unreachable cluster: lookups never return
+type blockingClient struct {
+	*repository.MemoryClient
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (c *blockingClient) LookupAccounts(ids []tb_types.Uint128) ([]tb_types.Account, error) {
+	c.calls.Add(1)
+	<-c.release
+	return nil, nil
+}
+
+func TestRepositoryPing(t *testing.T) {
+	t.Run("reachable", func(t *testing.T) {
+		repo := repository.NewInMemoryRepository()
+		assert.NoError(t, repo.Ping(context.Background()))
+
+		repo.Close()
+		assert.Error(t, repo.Ping(context.Background()))
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		client := &blockingClient{MemoryClient: repository.NewMemoryClient(), release: make(chan struct{})}
+		repo := repository.NewRepositoryWithClient(client)
+
+		for i := 0; i < 3; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			assert.ErrorIs(t, repo.Ping(ctx), context.DeadlineExceeded)
+			cancel()
+		}
+		// The blocked lookup is reused instead of piling up new ones
+		assert.Equal(t, int32(1), client.calls.Load())
+
+		close(client.release)
+		assert.Eventually(t, func() bool {
+			return repo.Ping(context.Background()) == nil
+		}, time.Second, time.Millisecond)
+	})
+}
+
+func TestRepositoryCreateReturnsStored(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+
+	debit, err := repo.CreateAccount(ctx, newAccount(1, 1, tb_types.AccountFlags{}))
+	require.NoError(t, err)
+	assert.NotZero(t, debit.Timestamp)
+	_, err = repo.CreateAccount(ctx, newAccount(2, 1, tb_types.AccountFlags{}))
+	require.NoError(t, err)
+
+	t.Run("balancing transfer reports the amount moved", func(t *testing.T) {
+		_, err := repo.CreateTransfer(ctx, newTransfer(10, 2, 1, 30, tb_types.TransferFlags{}))
+		require.NoError(t, err)
+
+		transfer, err := repo.CreateTransfer(ctx, newTransfer(11, 1, 2, 100, tb_types.TransferFlags{BalancingDebit: true}))
+		require.NoError(t, err)
+		assert.NotZero(t, transfer.Timestamp)
+		assert.Equal(t, "30", tbutil.Uint128ToString(transfer.Amount))
+	})
+
+	t.Run("post reports the pending accounts", func(t *testing.T) {
+		pending, err := repo.CreateTransfer(ctx, newTransfer(20, 2, 1, 5, tb_types.TransferFlags{Pending: true}))
+		require.NoError(t, err)
+
+		post := tb_types.Transfer{
+			ID:        tb_types.ToUint128(21),
+			PendingID: pending.ID,
+			Amount:    tb_types.ToUint128(5),
+			Flags:     tb_types.TransferFlags{PostPendingTransfer: true}.ToUint16(),
+		}
+		posted, err := repo.CreateTransfer(ctx, post)
+		require.NoError(t, err)
+		assert.Equal(t, pending.DebitAccountID, posted.DebitAccountID)
+		assert.Equal(t, pending.CreditAccountID, posted.CreditAccountID)
+		assert.Equal(t, uint32(1), posted.Ledger)
+		assert.NotZero(t, posted.Timestamp)
+	})
+}
+
+func TestRepositoryLinkedChainLogsFailureOnce(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger.Replace(zap.New(core))
+	t.Cleanup(func() { logger.Replace(zap.NewNop()) })
+
+	client := repository.NewMemoryClient()
+	_, err := client.CreateAccounts([]tb_types.Account{
+		newAccount(1, 1, tb_types.AccountFlags{}),
+		newAccount(2, 1, tb_types.AccountFlags{}),
+	})
+	require.NoError(t, err)
+	repo := repository.NewRepositoryWithClient(client)
+	t.Cleanup(repo.Close)
+
+	results, err := repo.CreateLinkedTransfers(context.Background(), []tb_types.Transfer{
+		newTransfer(10, 1, 2, 10, tb_types.TransferFlags{}),
+		newTransfer(11, 1, 9, 10, tb_types.TransferFlags{}),
+		newTransfer(12, 1, 2, 10, tb_types.TransferFlags{}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tb_types.TransferCreditAccountNotFound, results[1])
+
+	failures := logs.FilterMessage("linked chain failed").AllUntimed()
+	require.Len(t, failures, 1)
+	assert.Equal(t, int64(1), failures[0].ContextMap()["failed_index"])
+	assert.Equal(t, "credit_account_not_found", failures[0].ContextMap()["result"])
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Operation names reported to an Observer.
+const (
+	OpCreateAccounts      = "create_accounts"
+	OpCreateTransfers     = "create_transfers"
+	OpLookupAccounts      = "lookup_accounts"
+	OpLookupTransfers     = "lookup_transfers"
+	OpGetAccountTransfers = "get_account_transfers"
+	OpGetAccountBalances  = "get_account_balances"
+	OpQueryAccounts       = "query_accounts"
+	OpQueryTransfers      = "query_transfers"
+)
+
+// Observer receives every request the repository sends to the cluster, e.g.
+// to export metrics. Methods are called concurrently.
+type Observer interface {
+	// ObserveRequest reports a request of size events that took duration;
+	// err is the client error, if any.
+	ObserveRequest(operation string, size int, duration time.Duration, err error)
+	// ObserveResults reports how many events of a create request ended with
+	// each result name, including "ok".
+	ObserveResults(operation string, results map[string]int)
+	// ObserveTransferred reports an amount posted between two accounts of a
+	// ledger: the amount applied by a transfer, or by the post of a pending
+	// one. Pending reservations and voids are not reported. Balancing
+	// transfers and posts are only reported when created one at a time, as
+	// the amount they apply is read back from the stored transfer.
+	ObserveTransferred(ledger uint32, amount tb_types.Uint128)
+	// ObserveBatch reports size CreateTransfer calls coalesced into one
+	// request by WithTransferBatching, the first of which waited wait.
+	ObserveBatch(size int, wait time.Duration)
+}
+
+// Option configures a repository.
+type Option func(*TigerBeetleRepository)
+
+// WithObserver reports the repository's cluster requests to o.
+func WithObserver(o Observer) Option {
+	return func(r *TigerBeetleRepository) {
+		r.client = &observedClient{Client: r.client, observer: o}
+		r.observer = o
+	}
+}
+
+// observedClient reports every request of the wrapped client to an Observer
+type observedClient struct {
+	Client
+	observer Observer
+}
+
+func (c *observedClient) CreateAccounts(accounts []tb_types.Account) ([]tb_types.AccountEventResult, error) {
+	start := time.Now()
+	results, err := c.Client.CreateAccounts(accounts)
+	c.observer.ObserveRequest(OpCreateAccounts, len(accounts), time.Since(start), err)
+	if err != nil {
+		return results, err
+	}
+
+	counts := map[string]int{"ok": len(accounts) - len(results)}
+	for _, result := range results {
+		counts[tbutil.AccountResultName(result.Result)]++
+	}
+	c.observer.ObserveResults(OpCreateAccounts, counts)
+	return results, nil
+}
+
+func (c *observedClient) CreateTransfers(transfers []tb_types.Transfer) ([]tb_types.TransferEventResult, error) {
+	start := time.Now()
+	results, err := c.Client.CreateTransfers(transfers)
+	c.observer.ObserveRequest(OpCreateTransfers, len(transfers), time.Since(start), err)
+	if err != nil {
+		return results, err
+	}
+
+	failed := make(map[uint32]bool, len(results))
+	counts := map[string]int{"ok": len(transfers) - len(results)}
+	for _, result := range results {
+		failed[result.Index] = true
+		counts[tbutil.TransferResultName(result.Result)]++
+	}
+	c.observer.ObserveResults(OpCreateTransfers, counts)
+
+	// The amount of a balancing transfer is only an upper bound, and a post
+	// may inherit its amount and ledger from the pending transfer, so those
+	// are reported by the repository from the stored transfer.
+	for i, transfer := range transfers {
+		flags := transfer.TransferFlags()
+		if failed[uint32(i)] || flags.Pending || flags.VoidPendingTransfer || appliedOnStore(flags) {
+			continue
+		}
+		c.observer.ObserveTransferred(transfer.Ledger, transfer.Amount)
+	}
+	return results, nil
+}
+
+// appliedOnStore reports whether the amount and ledger a transfer applies
+// are only known once it is stored
+func appliedOnStore(flags tb_types.TransferFlags) bool {
+	return flags.BalancingDebit || flags.BalancingCredit || flags.PostPendingTransfer
+}
+
+// observeStored reports the amount applied by a balancing transfer or post,
+// read from the transfer as stored
+func (r *TigerBeetleRepository) observeStored(transfer *tb_types.Transfer) {
+	if r.observer != nil && appliedOnStore(transfer.TransferFlags()) {
+		r.observer.ObserveTransferred(transfer.Ledger, transfer.Amount)
+	}
+}
+
+func (c *observedClient) LookupAccounts(accountIDs []tb_types.Uint128) ([]tb_types.Account, error) {
+	start := time.Now()
+	accounts, err := c.Client.LookupAccounts(accountIDs)
+	c.observer.ObserveRequest(OpLookupAccounts, len(accountIDs), time.Since(start), err)
+	return accounts, err
+}
+
+func (c *observedClient) LookupTransfers(transferIDs []tb_types.Uint128) ([]tb_types.Transfer, error) {
+	start := time.Now()
+	transfers, err := c.Client.LookupTransfers(transferIDs)
+	c.observer.ObserveRequest(OpLookupTransfers, len(transferIDs), time.Since(start), err)
+	return transfers, err
+}
+
+func (c *observedClient) GetAccountTransfers(filter tb_types.AccountFilter) ([]tb_types.Transfer, error) {
+	start := time.Now()
+	transfers, err := c.Client.GetAccountTransfers(filter)
+	c.observer.ObserveRequest(OpGetAccountTransfers, 1, time.Since(start), err)
+	return transfers, err
+}
+
+func (c *observedClient) GetAccountBalances(filter tb_types.AccountFilter) ([]tb_types.AccountBalance, error) {
+	start := time.Now()
+	balances, err := c.Client.GetAccountBalances(filter)
+	c.observer.ObserveRequest(OpGetAccountBalances, 1, time.Since(start), err)
+	return balances, err
+}
+
+func (c *observedClient) QueryAccounts(filter tb_types.QueryFilter) ([]tb_types.Account, error) {
+	start := time.Now()
+	accounts, err := c.Client.QueryAccounts(filter)
+	c.observer.ObserveRequest(OpQueryAccounts, 1, time.Since(start), err)
+	return accounts, err
+}
+
+func (c *observedClient) QueryTransfers(filter tb_types.QueryFilter) ([]tb_types.Transfer, error) {
+	start := time.Now()
+	transfers, err := c.Client.QueryTransfers(filter)
+	c.observer.ObserveRequest(OpQueryTransfers, 1, time.Since(start), err)
+	return transfers, err
+}
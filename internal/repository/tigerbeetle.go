@@ -4,104 +4,364 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
 	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/validation"
 
 	tb "github.com/tigerbeetle/tigerbeetle-go"
 	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
 )
 
+// MaxBatchSize is the largest number of events the TigerBeetle client accepts
+// in a single request.
+const MaxBatchSize = 8189
+
 type TigerBeetleRepository struct {
-	client tb.Client
+	client   Client
+	observer Observer
+
+	// batcher coalesces CreateTransfer calls when batchWindow is set
+	batchWindow time.Duration
+	batchSize   int
+	batcher     *transferBatcher
+
+	pingMu sync.Mutex
+	ping   *probe
 }
 
-func NewTigerBeetleRepository(addresses []string, clusterID uint64) (*TigerBeetleRepository, error) {
+// probe is an outstanding Ping request, shared by every caller waiting on it
+type probe struct {
+	done chan struct{}
+	err  error
+}
+
+func NewTigerBeetleRepository(addresses []string, clusterID uint64, opts ...Option) (*TigerBeetleRepository, error) {
 	client, err := tb.NewClient(tb_types.ToUint128(clusterID), addresses)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create TigerBeetle client: %w", err)
 	}
 
-	return &TigerBeetleRepository{
+	return NewRepositoryWithClient(client, opts...), nil
+}
+
+// NewRepositoryWithClient builds a repository on top of an existing client.
+func NewRepositoryWithClient(client Client, opts ...Option) *TigerBeetleRepository {
+	r := &TigerBeetleRepository{
 		client: client,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.batchWindow > 0 {
+		r.batcher = newTransferBatcher(r.client, r.observer, r.batchWindow, r.batchSize)
+	}
+	return r
+}
+
+// NewInMemoryRepository builds a repository backed by a fresh MemoryClient.
+func NewInMemoryRepository(opts ...Option) *TigerBeetleRepository {
+	return NewRepositoryWithClient(NewMemoryClient(), opts...)
 }
 
 func (r *TigerBeetleRepository) Close() {
+	if r.batcher != nil {
+		r.batcher.close()
+	}
 	if r.client != nil {
 		r.client.Close()
 	}
 }
 
-func (r *TigerBeetleRepository) CreateAccount(ctx context.Context, account tb_types.Account) ([]tb_types.AccountEventResult, error) {
+// pingID is never a valid object ID, so looking it up is a cheap round trip
+// to the cluster that cannot match anything.
+var pingID = tbutil.MaxUint128
+
+// Ping checks that the cluster answers requests. The client retries an
+// unreachable cluster indefinitely, so Ping gives up when ctx is done; a
+// lookup still blocked from an earlier call is awaited rather than repeated.
+func (r *TigerBeetleRepository) Ping(ctx context.Context) error {
+	r.pingMu.Lock()
+	p := r.ping
+	if p == nil {
+		p = &probe{done: make(chan struct{})}
+		r.ping = p
+		go func() {
+			_, p.err = r.client.LookupAccounts([]tb_types.Uint128{pingID})
+			r.pingMu.Lock()
+			r.ping = nil
+			r.pingMu.Unlock()
+			close(p.done)
+		}()
+	}
+	r.pingMu.Unlock()
+
+	select {
+	case <-p.done:
+		if p.err != nil {
+			return fmt.Errorf("ping failed: %w", p.err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("ping failed: %w", ctx.Err())
+	}
+}
+
+// CreateAccount creates an account and returns it. Creating an account whose
+// ID already exists with identical fields is an idempotent replay and returns
+// the stored account; differing fields are reported as a conflict.
+func (r *TigerBeetleRepository) CreateAccount(ctx context.Context, account tb_types.Account) (_ *tb_types.Account, err error) {
+	ctx, span := startSpan(ctx, "CreateAccount", tracing.Ledger(account.Ledger), tracing.Code(account.Code))
+	defer func() { endSpan(span, err) }()
+
 	if err := validation.ValidateAccount(account); err != nil {
-		logger.Error("account validation failed", "error", err)
-		return nil, err
+		logger.ErrorContext(ctx, "account validation failed", "error", err)
+		return nil, &ValidationError{Err: err}
 	}
 
-	logger.Info("creating account", "id", account.ID, "ledger", account.Ledger)
+	logger.InfoContext(ctx, "creating account", "id", account.ID, "ledger", account.Ledger)
 
 	results, err := r.client.CreateAccounts([]tb_types.Account{account})
 	if err != nil {
-		logger.Error("error creating account", "error", err)
+		logger.ErrorContext(ctx, "error creating account", "error", err)
 		return nil, err
 	}
 
 	for _, result := range results {
-		if result.Result != 0 {
-			logger.Error("account creation failed", "result_code", result.Result, "id", account.ID)
-			return results, fmt.Errorf("account creation failed with code %d", result.Result)
+		if result.Result == tb_types.AccountExists {
+			logger.InfoContext(ctx, "account already exists, replaying", "id", account.ID)
+			return r.GetAccount(ctx, account.ID)
+		}
+		if result.Result != tb_types.AccountOK {
+			logger.ErrorContext(ctx, "account creation failed", "result_code", result.Result, "id", account.ID)
+			return nil, &AccountError{Result: result.Result}
 		}
 	}
 
-	return results, nil
+	// The ledger sets the timestamp, so return the account as stored
+	return r.GetAccount(ctx, account.ID)
 }
 
-func (r *TigerBeetleRepository) GetAccount(ctx context.Context, id tb_types.Uint128) (*tb_types.Account, error) {
-	logger.Debug("looking up account", "id", id)
+func (r *TigerBeetleRepository) GetAccount(ctx context.Context, id tb_types.Uint128) (_ *tb_types.Account, err error) {
+	ctx, span := startSpan(ctx, "GetAccount")
+	defer func() { endSpan(span, err) }()
+
+	logger.DebugContext(ctx, "looking up account", "id", id)
 
 	accounts, err := r.client.LookupAccounts([]tb_types.Uint128{id})
 	if err != nil {
-		logger.Error("failed to fetch account", "error", err)
+		logger.ErrorContext(ctx, "failed to fetch account", "error", err)
 		return nil, fmt.Errorf("failed to fetch account: %w", err)
 	}
 	if len(accounts) == 0 {
-		logger.Info("account not found", "id", id)
-		return nil, errors.New("account not found")
+		logger.InfoContext(ctx, "account not found", "id", id)
+		return nil, ErrAccountNotFound
 	}
 
 	return &accounts[0], nil
 }
 
-func (r *TigerBeetleRepository) CreateTransfer(ctx context.Context, transfer tb_types.Transfer) (*tb_types.Transfer, error) {
+// CreateTransfer creates a transfer and returns it. Like CreateAccount, an
+// identical retry returns the stored transfer instead of failing.
+func (r *TigerBeetleRepository) CreateTransfer(ctx context.Context, transfer tb_types.Transfer) (_ *tb_types.Transfer, err error) {
+	ctx, span := startSpan(ctx, "CreateTransfer", tracing.Ledger(transfer.Ledger), tracing.Code(transfer.Code))
+	defer func() { endSpan(span, err) }()
+
 	if err := validation.ValidateTransfer(transfer); err != nil {
-		logger.Error("transfer validation failed", "error", err)
-		return nil, err
+		logger.ErrorContext(ctx, "transfer validation failed", "error", err)
+		return nil, &ValidationError{Err: err}
 	}
 
-	logger.Info("creating transfer", "id", transfer.ID, "amount", transfer.Amount)
+	logger.InfoContext(ctx, "creating transfer", "id", transfer.ID, "amount", transfer.Amount)
 
-	_, err := r.client.CreateTransfers([]tb_types.Transfer{transfer})
+	result, err := r.createTransfer(ctx, transfer)
 	if err != nil {
-		logger.Error("error creating transfer", "error", err)
+		logger.ErrorContext(ctx, "error creating transfer", "error", err)
 		return nil, fmt.Errorf("failed to create transfer: %w", err)
 	}
 
-	return &transfer, nil
+	if result == tb_types.TransferExists {
+		logger.InfoContext(ctx, "transfer already exists, replaying", "id", transfer.ID)
+		return r.GetTransfer(ctx, transfer.ID)
+	}
+	if result != tb_types.TransferOK {
+		logger.ErrorContext(ctx, "transfer creation failed", "result_code", result, "id", transfer.ID)
+		return nil, &TransferError{Result: result}
+	}
+
+	// The ledger sets the timestamp and may change the request: balancing
+	// transfers move less than the amount, and posts and voids inherit the
+	// accounts of the pending transfer
+	stored, err := r.GetTransfer(ctx, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+	r.observeStored(stored)
+	return stored, nil
+}
+
+// createTransfer sends a single transfer, sharing a request with concurrent
+// callers when batching is enabled
+func (r *TigerBeetleRepository) createTransfer(ctx context.Context, transfer tb_types.Transfer) (tb_types.CreateTransferResult, error) {
+	if r.batcher != nil && r.batcher.accepts(transfer) {
+		return r.batcher.submit(ctx, transfer)
+	}
+
+	results, err := r.client.CreateTransfers([]tb_types.Transfer{transfer})
+	if err != nil {
+		return 0, err
+	}
+	if len(results) > 0 {
+		return results[0].Result, nil
+	}
+	return tb_types.TransferOK, nil
 }
 
-func (r *TigerBeetleRepository) GetTransfer(ctx context.Context, id tb_types.Uint128) (*tb_types.Transfer, error) {
-	logger.Debug("looking up transfer", "id", id)
+// CreateLinkedTransfers submits the transfers as a single linked chain, so
+// either all of them are applied or none is. The Linked flag is set on every
+// transfer but the last. It returns one result per transfer, in order; a
+// failed chain is reported through the results, not through the error.
+func (r *TigerBeetleRepository) CreateLinkedTransfers(ctx context.Context, transfers []tb_types.Transfer) (_ []tb_types.CreateTransferResult, err error) {
+	ctx, span := startSpan(ctx, "CreateLinkedTransfers", tracing.BatchSize(len(transfers)))
+	defer func() { endSpan(span, err) }()
+
+	if len(transfers) == 0 {
+		return nil, &ValidationError{Err: errors.New("linked chain must have at least one transfer")}
+	}
+	if len(transfers) > MaxBatchSize {
+		return nil, &ValidationError{Err: fmt.Errorf("linked chain cannot have more than %d transfers", MaxBatchSize)}
+	}
+
+	linked := tb_types.TransferFlags{Linked: true}.ToUint16()
+	chain := make([]tb_types.Transfer, len(transfers))
+	for i, transfer := range transfers {
+		if err := validation.ValidateTransfer(transfer); err != nil {
+			logger.ErrorContext(ctx, "transfer validation failed", "error", err, "index", i)
+			return nil, &ValidationError{Err: fmt.Errorf("transfer %d: %w", i, err)}
+		}
+
+		transfer.Flags |= linked
+		if i == len(transfers)-1 {
+			transfer.Flags &^= linked
+		}
+		chain[i] = transfer
+	}
+
+	logger.InfoContext(ctx, "creating linked transfers", "count", len(chain))
+
+	results, err := r.client.CreateTransfers(chain)
+	if err != nil {
+		logger.ErrorContext(ctx, "error creating linked transfers", "error", err)
+		return nil, fmt.Errorf("failed to create linked transfers: %w", err)
+	}
+
+	// A failed chain reports linked_event_failed for every transfer but the
+	// one that broke it
+	codes := make([]tb_types.CreateTransferResult, len(chain))
+	failedIndex := -1
+	for _, result := range results {
+		codes[result.Index] = result.Result
+		if result.Result != tb_types.TransferLinkedEventFailed && failedIndex < 0 {
+			failedIndex = int(result.Index)
+		}
+	}
+	if failedIndex >= 0 {
+		name := tbutil.TransferResultName(codes[failedIndex])
+		logger.ErrorContext(ctx, "linked chain failed", "result", name, "result_code", codes[failedIndex],
+			"failed_index", failedIndex, "count", len(chain))
+		span.SetAttributes(tracing.Result(name))
+	}
+
+	return codes, nil
+}
+
+func (r *TigerBeetleRepository) GetTransfer(ctx context.Context, id tb_types.Uint128) (_ *tb_types.Transfer, err error) {
+	ctx, span := startSpan(ctx, "GetTransfer")
+	defer func() { endSpan(span, err) }()
+
+	logger.DebugContext(ctx, "looking up transfer", "id", id)
 
 	transfers, err := r.client.LookupTransfers([]tb_types.Uint128{id})
 	if err != nil {
-		logger.Error("failed to fetch transfer", "error", err)
+		logger.ErrorContext(ctx, "failed to fetch transfer", "error", err)
 		return nil, fmt.Errorf("failed to fetch transfer: %w", err)
 	}
 	if len(transfers) == 0 {
-		logger.Info("transfer not found", "id", id)
-		return nil, errors.New("transfer not found")
+		logger.InfoContext(ctx, "transfer not found", "id", id)
+		return nil, ErrTransferNotFound
 	}
 
 	return &transfers[0], nil
 }
+
+// GetAccountTransfers returns the transfers that debit and/or credit an
+// account, as selected by the filter flags, timestamp range and limit.
+func (r *TigerBeetleRepository) GetAccountTransfers(ctx context.Context, filter tb_types.AccountFilter) (_ []tb_types.Transfer, err error) {
+	ctx, span := startSpan(ctx, "GetAccountTransfers")
+	defer func() { endSpan(span, err) }()
+
+	logger.DebugContext(ctx, "listing account transfers", "account_id", filter.AccountID, "limit", filter.Limit)
+
+	transfers, err := r.client.GetAccountTransfers(filter)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list account transfers", "error", err)
+		return nil, fmt.Errorf("failed to list account transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// GetAccountBalances returns the historical balances of an account created
+// with the History flag, one per transfer matching the filter. Accounts
+// without the flag have no history and yield an empty result.
+func (r *TigerBeetleRepository) GetAccountBalances(ctx context.Context, filter tb_types.AccountFilter) (_ []tb_types.AccountBalance, err error) {
+	ctx, span := startSpan(ctx, "GetAccountBalances")
+	defer func() { endSpan(span, err) }()
+
+	logger.DebugContext(ctx, "listing account balances", "account_id", filter.AccountID, "limit", filter.Limit)
+
+	balances, err := r.client.GetAccountBalances(filter)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list account balances", "error", err)
+		return nil, fmt.Errorf("failed to list account balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// QueryAccounts returns the accounts matching every non-zero field of the
+// filter, in timestamp order or reversed.
+func (r *TigerBeetleRepository) QueryAccounts(ctx context.Context, filter tb_types.QueryFilter) (_ []tb_types.Account, err error) {
+	ctx, span := startSpan(ctx, "QueryAccounts", tracing.Ledger(filter.Ledger), tracing.Code(filter.Code))
+	defer func() { endSpan(span, err) }()
+
+	logger.DebugContext(ctx, "querying accounts", "ledger", filter.Ledger, "code", filter.Code, "limit", filter.Limit)
+
+	accounts, err := r.client.QueryAccounts(filter)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to query accounts", "error", err)
+		return nil, fmt.Errorf("failed to query accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// QueryTransfers returns the transfers matching every non-zero field of the
+// filter, in timestamp order or reversed.
+func (r *TigerBeetleRepository) QueryTransfers(ctx context.Context, filter tb_types.QueryFilter) (_ []tb_types.Transfer, err error) {
+	ctx, span := startSpan(ctx, "QueryTransfers", tracing.Ledger(filter.Ledger), tracing.Code(filter.Code))
+	defer func() { endSpan(span, err) }()
+
+	logger.DebugContext(ctx, "querying transfers", "ledger", filter.Ledger, "code", filter.Code, "limit", filter.Limit)
+
+	transfers, err := r.client.QueryTransfers(filter)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to query transfers", "error", err)
+		return nil, fmt.Errorf("failed to query transfers: %w", err)
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository")
+
+// startSpan starts the span of a repository call, a child of the span in ctx
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "TigerBeetleRepository."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+// endSpan ends a repository span, tagging ledger rejections with the
+// TigerBeetle result name
+func endSpan(span trace.Span, err error) {
+	var accountErr *AccountError
+	var transferErr *TransferError
+	switch {
+	case errors.As(err, &accountErr):
+		span.SetAttributes(tracing.Result(tbutil.AccountResultName(accountErr.Result)))
+	case errors.As(err, &transferErr):
+		span.SetAttributes(tracing.Result(tbutil.TransferResultName(transferErr.Result)))
+	}
+	tracing.End(span, err)
+}
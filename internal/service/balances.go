@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+
+	. "github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetAccountBalances pages through the historical balances of an account
+// created with the history flag, one balance per transfer
+func (s *FinancialService) GetAccountBalances(ctx context.Context, req *pb.GetAccountBalancesRequest) (_ *pb.GetAccountBalancesResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.GetAccountBalances")
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	filter := tb_types.AccountFilter{
+		AccountID: fields.id("account_id", req.AccountId),
+		Flags:     filterFlags(req.Direction, req.Reversed),
+	}
+	page := pageRequest{req.TimestampMin, req.TimestampMax, req.Limit, req.Reversed, req.PageToken}
+	balances, next, err := paginate(fields, page, func(timestampMin, timestampMax uint64, limit uint32) ([]tb_types.AccountBalance, error) {
+		filter.TimestampMin, filter.TimestampMax, filter.Limit = timestampMin, timestampMax, limit
+		return s.repo.GetAccountBalances(ctx, filter)
+	}, func(balance *tb_types.AccountBalance) uint64 { return balance.Timestamp })
+	if err != nil {
+		return nil, err
+	}
+
+	// The ledger returns no balances both for accounts without history and
+	// for empty ranges; tell the two apart on the first page
+	if len(balances) == 0 && req.PageToken == "" {
+		if err := s.requireHistory(ctx, filter.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &pb.GetAccountBalancesResponse{
+		Balances:      make([]*pb.AccountBalance, len(balances)),
+		NextPageToken: next,
+	}
+	for i := range balances {
+		response.Balances[i] = toAccountBalance(balances[i])
+	}
+
+	return response, nil
+}
+
+// GetBalanceAt returns the balance of an account with the history flag as of
+// the given timestamp, i.e. after the last transfer at or before it
+func (s *FinancialService) GetBalanceAt(ctx context.Context, req *pb.GetBalanceAtRequest) (_ *pb.AccountBalance, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.GetBalanceAt")
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	accountId := fields.id("account_id", req.AccountId)
+	if req.Timestamp == 0 {
+		fields.add("timestamp", "is required")
+	}
+	if err := fields.err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.requireHistory(ctx, accountId); err != nil {
+		return nil, err
+	}
+
+	balances, err := s.repo.GetAccountBalances(ctx, tb_types.AccountFilter{
+		AccountID:    accountId,
+		TimestampMax: req.Timestamp,
+		Limit:        1,
+		Flags:        filterFlags(pb.TransferDirection_TRANSFER_DIRECTION_BOTH, true),
+	})
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	// No transfer before the timestamp: every balance was still zero
+	if len(balances) == 0 {
+		return toAccountBalance(tb_types.AccountBalance{}), nil
+	}
+
+	return toAccountBalance(balances[0]), nil
+}
+
+// requireHistory checks that an account exists and keeps balance history
+func (s *FinancialService) requireHistory(ctx context.Context, id tb_types.Uint128) error {
+	account, err := s.repo.GetAccount(ctx, id)
+	if err != nil {
+		return statusFromError(err)
+	}
+	if !account.AccountFlags().History {
+		return status.Error(codes.FailedPrecondition, "Account was not created with the history flag")
+	}
+	return nil
+}
+
+// filterFlags builds account filter flags for a transfer direction
+func filterFlags(direction pb.TransferDirection, reversed bool) uint32 {
+	flags := tb_types.AccountFilterFlags{Reversed: reversed}
+	switch direction {
+	case pb.TransferDirection_TRANSFER_DIRECTION_DEBITS:
+		flags.Debits = true
+	case pb.TransferDirection_TRANSFER_DIRECTION_CREDITS:
+		flags.Credits = true
+	default:
+		flags.Debits = true
+		flags.Credits = true
+	}
+	return flags.ToUint32()
+}
+
+func toAccountBalance(balance tb_types.AccountBalance) *pb.AccountBalance {
+	return &pb.AccountBalance{
+		DebitsPending:  Uint128ToString(balance.DebitsPending),
+		DebitsPosted:   Uint128ToString(balance.DebitsPosted),
+		CreditsPending: Uint128ToString(balance.CreditsPending),
+		CreditsPosted:  Uint128ToString(balance.CreditsPosted),
+		Timestamp:      balance.Timestamp,
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+
+	. "github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+// resultNotAttempted names the result of a batch item that was never sent
+const resultNotAttempted = "not_attempted"
+
+// CreateAccountsBatch creates many accounts in as few ledger requests as
+// possible and returns one result per account
+func (s *FinancialService) CreateAccountsBatch(ctx context.Context, req *pb.CreateAccountsBatchRequest) (_ *pb.BatchResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.CreateAccountsBatch",
+		trace.WithAttributes(tracing.BatchSize(len(req.Accounts))))
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	if len(req.Accounts) == 0 {
+		fields.add("accounts", "at least one account is required")
+	}
+
+	accounts := make([]tb_types.Account, len(req.Accounts))
+	for i, item := range req.Accounts {
+		accounts[i] = accountFromRequest(fields.at("accounts", i), item)
+	}
+	if err := fields.err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]tb_types.Uint128, len(accounts))
+	for i, account := range accounts {
+		ids[i] = account.ID
+	}
+	results, err := s.repo.CreateAccounts(ctx, accounts)
+	return batchResponse(ctx, ids, results, err, tb_types.AccountOK, tb_types.AccountExists, AccountResultName)
+}
+
+// CreateTransfersBatch creates many transfers in as few ledger requests as
+// possible and returns one result per transfer
+func (s *FinancialService) CreateTransfersBatch(ctx context.Context, req *pb.CreateTransfersBatchRequest) (_ *pb.BatchResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.CreateTransfersBatch",
+		trace.WithAttributes(tracing.BatchSize(len(req.Transfers))))
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	if len(req.Transfers) == 0 {
+		fields.add("transfers", "at least one transfer is required")
+	}
+
+	transfers := make([]tb_types.Transfer, len(req.Transfers))
+	for i, item := range req.Transfers {
+		transfers[i] = transferFromRequest(fields.at("transfers", i), item)
+	}
+	if err := fields.err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]tb_types.Uint128, len(transfers))
+	for i, transfer := range transfers {
+		ids[i] = transfer.ID
+	}
+	results, err := s.repo.CreateTransfers(ctx, transfers)
+	return batchResponse(ctx, ids, results, err, tb_types.TransferOK, tb_types.TransferExists, TransferResultName)
+}
+
+// batchResponse reports one result per item. Items that exist unchanged
+// count as already existed, so retrying a batch reports no failures. If the
+// ledger failed after committing part of the batch, the remaining items are
+// reported as not attempted; if it failed before, the error is returned.
+func batchResponse[R ~uint32](ctx context.Context, ids []tb_types.Uint128, results []R, err error, ok, exists R, name func(R) string) (*pb.BatchResponse, error) {
+	if err != nil && len(results) == 0 {
+		return nil, statusFromError(err)
+	}
+
+	response := &pb.BatchResponse{Results: make([]*pb.BatchItemResult, len(ids))}
+	for i, id := range ids {
+		item := &pb.BatchItemResult{Index: uint32(i), Id: Uint128ToString(id)}
+		response.Results[i] = item
+		if i >= len(results) {
+			item.Result = resultNotAttempted
+			item.NotAttempted = true
+			response.NotAttempted++
+			continue
+		}
+
+		item.ResultCode = uint32(results[i])
+		item.Result = name(results[i])
+		switch results[i] {
+		case ok:
+			response.Created++
+		case exists:
+			response.AlreadyExisted++
+		default:
+			response.Failed++
+		}
+	}
+
+	if err != nil {
+		response.ErrorMessage = status.Convert(statusFromError(err)).Message()
+		logger.WarnContext(ctx, "batch partially committed", "error", err,
+			"committed", len(results), "not_attempted", response.NotAttempted)
+	}
+	return response, nil
+}
+
+func accountFromRequest(fields fieldErrors, req *pb.CreateAccountRequest) tb_types.Account {
+	return tb_types.Account{
+		ID:          fields.newID("id", req.Id),
+		UserData128: fields.userData("user_data_128", req.UserData_128),
+		UserData64:  req.UserData_64,
+		UserData32:  req.UserData_32,
+		Ledger:      req.Ledger,
+		Code:        fields.uint16("code", req.Code),
+		Flags:       fields.uint16("flags", req.Flags),
+	}
+}
+
+func transferFromRequest(fields fieldErrors, req *pb.CreateTransferRequest) tb_types.Transfer {
+	// Code and account IDs may be omitted when posting or voiding a pending
+	// transfer, which inherits them
+	resolvesPending := req.PendingId != ""
+
+	transfer := tb_types.Transfer{
+		ID:          fields.newID("id", req.Id),
+		PendingID:   fields.optionalID("pending_id", req.PendingId),
+		Amount:      fields.amount("amount", req.Amount),
+		UserData128: fields.userData("user_data_128", req.UserData_128),
+		UserData64:  req.UserData_64,
+		UserData32:  req.UserData_32,
+		Timeout:     req.Timeout,
+		Ledger:      req.Ledger,
+		Code:        fields.code("code", req.Code, !resolvesPending),
+		Flags:       fields.uint16("flags", req.Flags),
+	}
+	if resolvesPending {
+		transfer.DebitAccountID = fields.optionalID("debit_account_id", req.DebitAccountId)
+		transfer.CreditAccountID = fields.optionalID("credit_account_id", req.CreditAccountId)
+	} else {
+		transfer.DebitAccountID = fields.id("debit_account_id", req.DebitAccountId)
+		transfer.CreditAccountID = fields.id("credit_account_id", req.CreditAccountId)
+	}
+	return transfer
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	. "github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fieldErrors collects the violations found while decoding a request, so that
+// a client learns about every malformed field at once. Copies made with at
+// share the same list and prefix their field paths, e.g. "legs[2].amount".
+type fieldErrors struct {
+	prefix     string
+	violations *[]*errdetails.BadRequest_FieldViolation
+}
+
+func newFieldErrors() fieldErrors {
+	return fieldErrors{violations: new([]*errdetails.BadRequest_FieldViolation)}
+}
+
+// at returns a collector for the element at index of a repeated field
+func (f fieldErrors) at(field string, index int) fieldErrors {
+	return fieldErrors{
+		prefix:     fmt.Sprintf("%s%s[%d].", f.prefix, field, index),
+		violations: f.violations,
+	}
+}
+
+func (f fieldErrors) add(field, description string) {
+	*f.violations = append(*f.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       f.prefix + field,
+		Description: description,
+	})
+}
+
+// err returns an InvalidArgument status carrying a google.rpc.BadRequest with
+// every violation, or nil when the request was well formed
+func (f fieldErrors) err() error {
+	if len(*f.violations) == 0 {
+		return nil
+	}
+
+	parts := make([]string, len(*f.violations))
+	for i, v := range *f.violations {
+		parts[i] = v.Field + ": " + v.Description
+	}
+
+	st := status.New(codes.InvalidArgument, "Invalid request: "+strings.Join(parts, "; "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: *f.violations})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// id decodes a required, non-zero ID in decimal or UUID form
+func (f fieldErrors) id(field, s string) tb_types.Uint128 {
+	if s == "" {
+		f.add(field, "is required")
+		return tb_types.Uint128{}
+	}
+	return f.nonZeroID(field, s)
+}
+
+// optionalID decodes an ID that may be omitted, in which case it is zero
+func (f fieldErrors) optionalID(field, s string) tb_types.Uint128 {
+	if s == "" {
+		return tb_types.Uint128{}
+	}
+	return f.nonZeroID(field, s)
+}
+
+// newID decodes the ID of an object being created, generating one when the
+// client did not supply it
+func (f fieldErrors) newID(field, s string) tb_types.Uint128 {
+	if s == "" {
+		return tb_types.ID()
+	}
+	return f.nonZeroID(field, s)
+}
+
+func (f fieldErrors) nonZeroID(field, s string) tb_types.Uint128 {
+	id, err := ParseID(s)
+	if err != nil {
+		f.add(field, "must be a decimal 128-bit integer or a UUID")
+		return tb_types.Uint128{}
+	}
+	if id == (tb_types.Uint128{}) {
+		f.add(field, "must not be zero")
+	}
+	return id
+}
+
+// userData decodes an optional user_data_128 value in decimal or UUID form.
+// Unlike IDs it may be zero.
+func (f fieldErrors) userData(field, s string) tb_types.Uint128 {
+	if s == "" {
+		return tb_types.Uint128{}
+	}
+	u, err := ParseID(s)
+	if err != nil {
+		f.add(field, "must be a decimal 128-bit integer or a UUID")
+	}
+	return u
+}
+
+// amount decodes a decimal amount of up to 128 bits, treating an empty
+// string as zero
+func (f fieldErrors) amount(field, s string) tb_types.Uint128 {
+	if s == "" {
+		return tb_types.Uint128{}
+	}
+	amount, err := ParseUint128FromString(s)
+	if err != nil {
+		f.add(field, "must be a non-negative decimal integer of at most 128 bits")
+	}
+	return amount
+}
+
+// code decodes a decimal user code
+func (f fieldErrors) code(field, s string, required bool) uint16 {
+	if s == "" {
+		if required {
+			f.add(field, "is required")
+		}
+		return 0
+	}
+	code, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		f.add(field, "must be a decimal integer between 0 and 65535")
+	}
+	return uint16(code)
+}
+
+// uint16 narrows a proto uint32 field that is 16 bits wide in the ledger
+func (f fieldErrors) uint16(field string, v uint32) uint16 {
+	if v > math.MaxUint16 {
+		f.add(field, "must be at most 65535")
+	}
+	return uint16(v)
+}
+
+// pageToken decodes an optional page token
+func (f fieldErrors) pageToken(field, s string) (uint64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	cursor, err := decodePageToken(s)
+	if err != nil {
+		f.add(field, "is not a token returned by a previous page")
+		return 0, false
+	}
+	return cursor, true
+}
@@ -0,0 +1,261 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// violatedFields returns the fields listed in the BadRequest details of err
+func violatedFields(t *testing.T, err error) []string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a status error: %v", err)
+	require.Equal(t, codes.InvalidArgument, st.Code(), st.Message())
+
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+		}
+	}
+	return fields
+}
+
+func TestMalformedRequests(t *testing.T) {
+	ctx := context.Background()
+	svc, _, debit, credit := newTestService(t)
+
+	// Malformed IDs and amounts: not a number, UUID-shaped with a misplaced
+	// hyphen, and one past the largest 128-bit value
+	bad := []string{
+		"abc",
+		"00000000-0000-0000-0000-0000000--000",
+		"340282366920938463463374607431768211456",
+	}
+
+	tests := []struct {
+		name   string
+		call   func(bad string) error
+		fields []string
+	}{
+		{
+			name: "CreateAccount",
+			call: func(bad string) error {
+				_, err := svc.CreateAccount(ctx, &pb.CreateAccountRequest{Id: bad, Code: 70000, Flags: 1 << 20, Ledger: 1})
+				return err
+			},
+			fields: []string{"id", "code", "flags"},
+		},
+		{
+			name: "GetAccount",
+			call: func(bad string) error {
+				_, err := svc.GetAccount(ctx, &pb.GetAccountRequest{Id: bad})
+				return err
+			},
+			fields: []string{"id"},
+		},
+		{
+			name: "GetAccount missing ID",
+			call: func(bad string) error {
+				_, err := svc.GetAccount(ctx, &pb.GetAccountRequest{})
+				return err
+			},
+			fields: []string{"id"},
+		},
+		{
+			name: "CreateTransfer",
+			call: func(bad string) error {
+				_, err := svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+					DebitAccountId: bad, CreditAccountId: "-1", Amount: bad, Code: "x", Ledger: 1,
+				})
+				return err
+			},
+			fields: []string{"amount", "code", "debit_account_id", "credit_account_id"},
+		},
+		{
+			name: "GetTransfer",
+			call: func(bad string) error {
+				_, err := svc.GetTransfer(ctx, &pb.GetTransferRequest{Id: bad})
+				return err
+			},
+			fields: []string{"id"},
+		},
+		{
+			name: "ReserveFunds",
+			call: func(bad string) error {
+				_, err := svc.ReserveFunds(ctx, &pb.ReserveFundsRequest{
+					DebitAccountId: debit, CreditAccountId: bad, Amount: "1", Code: "1", Ledger: 1,
+				})
+				return err
+			},
+			fields: []string{"credit_account_id"},
+		},
+		{
+			name: "CapturePending",
+			call: func(bad string) error {
+				_, err := svc.CapturePending(ctx, &pb.CapturePendingRequest{PendingId: bad, Amount: bad})
+				return err
+			},
+			fields: []string{"pending_id", "amount"},
+		},
+		{
+			name: "VoidPending",
+			call: func(bad string) error {
+				_, err := svc.VoidPending(ctx, &pb.VoidPendingRequest{Id: bad, PendingId: "0"})
+				return err
+			},
+			fields: []string{"id", "pending_id"},
+		},
+		{
+			name: "CreateLinkedTransfers",
+			call: func(bad string) error {
+				_, err := svc.CreateLinkedTransfers(ctx, &pb.CreateLinkedTransfersRequest{Legs: []*pb.TransferLeg{
+					{DebitAccountId: debit, CreditAccountId: credit, Amount: "1", Code: "1", Ledger: 1},
+					{DebitAccountId: debit, CreditAccountId: bad, Amount: "1", Code: "1", Ledger: 1},
+				}})
+				return err
+			},
+			fields: []string{"legs[1].credit_account_id"},
+		},
+		{
+			name: "CreateLinkedTransfers empty",
+			call: func(bad string) error {
+				_, err := svc.CreateLinkedTransfers(ctx, &pb.CreateLinkedTransfersRequest{})
+				return err
+			},
+			fields: []string{"legs"},
+		},
+		{
+			name: "CreateAccountsBatch",
+			call: func(bad string) error {
+				_, err := svc.CreateAccountsBatch(ctx, &pb.CreateAccountsBatchRequest{Accounts: []*pb.CreateAccountRequest{
+					{Code: 1, Ledger: 1},
+					{Id: bad, Code: 1, Ledger: 1},
+				}})
+				return err
+			},
+			fields: []string{"accounts[1].id"},
+		},
+		{
+			name: "CreateTransfersBatch",
+			call: func(bad string) error {
+				_, err := svc.CreateTransfersBatch(ctx, &pb.CreateTransfersBatchRequest{Transfers: []*pb.CreateTransferRequest{
+					{DebitAccountId: debit, CreditAccountId: credit, Amount: bad, Code: "1", Ledger: 1},
+				}})
+				return err
+			},
+			fields: []string{"transfers[0].amount"},
+		},
+		{
+			name: "ListAccountTransfers",
+			call: func(bad string) error {
+				_, err := svc.ListAccountTransfers(ctx, &pb.ListAccountTransfersRequest{AccountId: bad, PageToken: "!"})
+				return err
+			},
+			fields: []string{"account_id", "page_token"},
+		},
+		{
+			name: "GetAccountBalances",
+			call: func(bad string) error {
+				_, err := svc.GetAccountBalances(ctx, &pb.GetAccountBalancesRequest{AccountId: bad})
+				return err
+			},
+			fields: []string{"account_id"},
+		},
+		{
+			name: "GetBalanceAt",
+			call: func(bad string) error {
+				_, err := svc.GetBalanceAt(ctx, &pb.GetBalanceAtRequest{AccountId: bad})
+				return err
+			},
+			fields: []string{"account_id", "timestamp"},
+		},
+		{
+			name: "QueryAccounts",
+			call: func(bad string) error {
+				_, err := svc.QueryAccounts(ctx, &pb.QueryAccountsRequest{UserData_128: bad, Code: 70000, PageToken: bad})
+				return err
+			},
+			fields: []string{"user_data_128", "code", "page_token"},
+		},
+		{
+			name: "QueryTransfers",
+			call: func(bad string) error {
+				_, err := svc.QueryTransfers(ctx, &pb.QueryTransfersRequest{UserData_128: bad, Code: 70000, PageToken: bad})
+				return err
+			},
+			fields: []string{"user_data_128", "code", "page_token"},
+		},
+	}
+
+	for _, value := range bad {
+		for _, tt := range tests {
+			t.Run(tt.name+"/"+value, func(t *testing.T) {
+				var err error
+				require.NotPanics(t, func() { err = tt.call(value) })
+				assert.ElementsMatch(t, tt.fields, violatedFields(t, err))
+			})
+		}
+	}
+
+	// Imports report malformed records in the summary instead of failing
+	timestamp := uint64(time.Now().Add(-time.Hour).UnixNano())
+	for _, value := range bad {
+		t.Run("ImportAccounts/"+value, func(t *testing.T) {
+			stream := &importStream[pb.ImportAccountRecord]{records: []*pb.ImportAccountRecord{
+				{Account: &pb.CreateAccountRequest{Id: value, Ledger: 1, Code: 1}, Timestamp: timestamp},
+			}}
+			require.NotPanics(t, func() { require.NoError(t, svc.ImportAccounts(stream)) })
+			require.Len(t, stream.summary.Failures, 1)
+			assert.Equal(t, "VALIDATION_FAILED", stream.summary.Failures[0].Reason)
+		})
+
+		t.Run("ImportTransfers/"+value, func(t *testing.T) {
+			stream := &importStream[pb.ImportTransferRecord]{records: []*pb.ImportTransferRecord{
+				{Transfer: &pb.CreateTransferRequest{
+					Id: "1", DebitAccountId: value, CreditAccountId: credit, Amount: value, Code: "1", Ledger: 1,
+				}, Timestamp: timestamp},
+			}}
+			require.NotPanics(t, func() { require.NoError(t, svc.ImportTransfers(stream)) })
+			require.Len(t, stream.summary.Failures, 1)
+			assert.Equal(t, "VALIDATION_FAILED", stream.summary.Failures[0].Reason)
+		})
+	}
+
+	t.Run("GetImportCheckpoint", func(t *testing.T) {
+		var err error
+		require.NotPanics(t, func() {
+			_, err = svc.GetImportCheckpoint(ctx, &pb.GetImportCheckpointRequest{Kind: pb.ImportKind(42)})
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCreateTransferResolvingPendingNeedsNoAccounts(t *testing.T) {
+	ctx := context.Background()
+	svc, _, debit, credit := newTestService(t)
+
+	pending, err := svc.ReserveFunds(ctx, &pb.ReserveFundsRequest{
+		DebitAccountId: debit, CreditAccountId: credit, Amount: "10", Code: "1", Ledger: 1,
+	})
+	require.NoError(t, err)
+
+	// Post with only the pending ID: accounts, ledger and code are inherited
+	_, err = svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+		PendingId: pending.Id,
+		Amount:    "10",
+		Flags:     uint32(tb_types.TransferFlags{PostPendingTransfer: true}.ToUint16()),
+	})
+	assert.NoError(t, err)
+}
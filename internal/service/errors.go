@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+
+	. "github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies this service in the google.rpc.ErrorInfo details
+const errorDomain = "tigerbeetle-service"
+
+// Reasons for failures that do not come from a TigerBeetle result code
+const (
+	reasonAccountNotFound  = "ACCOUNT_NOT_FOUND"
+	reasonTransferNotFound = "TRANSFER_NOT_FOUND"
+	reasonValidationFailed = "VALIDATION_FAILED"
+)
+
+// statusFromError converts a repository error into a gRPC status error. Ledger
+// rejections carry a google.rpc.ErrorInfo whose reason is the upper-cased
+// TigerBeetle result name, e.g. EXCEEDS_CREDITS, so clients can branch on it
+// without parsing messages.
+func statusFromError(err error) error {
+	var accountErr *repository.AccountError
+	if errors.As(err, &accountErr) {
+		name := AccountResultName(accountErr.Result)
+		return withReason(accountResultCode(accountErr.Result), messageFor(err), name, resultMetadata(name, uint32(accountErr.Result)))
+	}
+
+	var transferErr *repository.TransferError
+	if errors.As(err, &transferErr) {
+		name := TransferResultName(transferErr.Result)
+		return withReason(transferResultCode(transferErr.Result), messageFor(err), name, resultMetadata(name, uint32(transferErr.Result)))
+	}
+
+	var validationErr *repository.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return withReason(codes.InvalidArgument, err.Error(), reasonValidationFailed, nil)
+	case errors.Is(err, repository.ErrAccountNotFound):
+		return withReason(codes.NotFound, err.Error(), reasonAccountNotFound, nil)
+	case errors.Is(err, repository.ErrTransferNotFound):
+		return withReason(codes.NotFound, err.Error(), reasonTransferNotFound, nil)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func messageFor(err error) string {
+	if repository.IsConflict(err) {
+		return "ID already used with different fields: " + err.Error()
+	}
+	return err.Error()
+}
+
+func resultMetadata(name string, code uint32) map[string]string {
+	return map[string]string{
+		"result":      name,
+		"result_code": strconv.FormatUint(uint64(code), 10),
+	}
+}
+
+// withReason builds a status carrying an ErrorInfo with the given reason
+func withReason(code codes.Code, message, reason string, metadata map[string]string) error {
+	info := &errdetails.ErrorInfo{
+		Reason:   strings.ToUpper(reason),
+		Domain:   errorDomain,
+		Metadata: metadata,
+	}
+
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(info)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// accountResultCode maps a failed account result to a gRPC code. Results not
+// listed here are problems with the request itself.
+func accountResultCode(result tb_types.CreateAccountResult) codes.Code {
+	switch result {
+	case tb_types.AccountExists,
+		tb_types.AccountExistsWithDifferentFlags,
+		tb_types.AccountExistsWithDifferentUserData128,
+		tb_types.AccountExistsWithDifferentUserData64,
+		tb_types.AccountExistsWithDifferentUserData32,
+		tb_types.AccountExistsWithDifferentLedger,
+		tb_types.AccountExistsWithDifferentCode:
+		return codes.AlreadyExists
+	case tb_types.AccountLinkedEventFailed:
+		return codes.Aborted
+	case tb_types.AccountImportedEventTimestampMustNotAdvance,
+		tb_types.AccountImportedEventTimestampMustNotRegress:
+		return codes.FailedPrecondition
+	}
+	return codes.InvalidArgument
+}
+
+// transferResultCode maps a failed transfer result to a gRPC code: missing
+// objects are NotFound, insufficient funds and results that depend on the
+// current state of the ledger are FailedPrecondition, and the rest are
+// problems with the request itself.
+func transferResultCode(result tb_types.CreateTransferResult) codes.Code {
+	switch result {
+	case tb_types.TransferExists,
+		tb_types.TransferExistsWithDifferentFlags,
+		tb_types.TransferExistsWithDifferentPendingID,
+		tb_types.TransferExistsWithDifferentTimeout,
+		tb_types.TransferExistsWithDifferentDebitAccountID,
+		tb_types.TransferExistsWithDifferentCreditAccountID,
+		tb_types.TransferExistsWithDifferentAmount,
+		tb_types.TransferExistsWithDifferentUserData128,
+		tb_types.TransferExistsWithDifferentUserData64,
+		tb_types.TransferExistsWithDifferentUserData32,
+		tb_types.TransferExistsWithDifferentLedger,
+		tb_types.TransferExistsWithDifferentCode,
+		tb_types.TransferIDAlreadyFailed:
+		return codes.AlreadyExists
+	case tb_types.TransferDebitAccountNotFound,
+		tb_types.TransferCreditAccountNotFound,
+		tb_types.TransferPendingTransferNotFound:
+		return codes.NotFound
+	case tb_types.TransferLinkedEventFailed:
+		return codes.Aborted
+	case tb_types.TransferExceedsCredits,
+		tb_types.TransferExceedsDebits,
+		tb_types.TransferExceedsPendingTransferAmount,
+		tb_types.TransferAccountsMustHaveTheSameLedger,
+		tb_types.TransferTransferMustHaveTheSameLedgerAsAccounts,
+		tb_types.TransferPendingTransferNotPending,
+		tb_types.TransferPendingTransferHasDifferentDebitAccountID,
+		tb_types.TransferPendingTransferHasDifferentCreditAccountID,
+		tb_types.TransferPendingTransferHasDifferentLedger,
+		tb_types.TransferPendingTransferHasDifferentCode,
+		tb_types.TransferPendingTransferHasDifferentAmount,
+		tb_types.TransferPendingTransferAlreadyPosted,
+		tb_types.TransferPendingTransferAlreadyVoided,
+		tb_types.TransferPendingTransferExpired,
+		tb_types.TransferDebitAccountAlreadyClosed,
+		tb_types.TransferCreditAccountAlreadyClosed,
+		tb_types.TransferOverflowsDebitsPending,
+		tb_types.TransferOverflowsCreditsPending,
+		tb_types.TransferOverflowsDebitsPosted,
+		tb_types.TransferOverflowsCreditsPosted,
+		tb_types.TransferOverflowsDebits,
+		tb_types.TransferOverflowsCredits,
+		tb_types.TransferOverflowsTimeout,
+		tb_types.TransferImportedEventTimestampMustNotAdvance,
+		tb_types.TransferImportedEventTimestampMustNotRegress,
+		tb_types.TransferImportedEventTimestampMustPostdateDebitAccount,
+		tb_types.TransferImportedEventTimestampMustPostdateCreditAccount:
+		return codes.FailedPrecondition
+	}
+	return codes.InvalidArgument
+}
@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorReason returns the code of a status error and the reason of its
+// ErrorInfo detail
+func errorReason(t *testing.T, err error) (codes.Code, string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a status error: %v", err)
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return st.Code(), info.Reason
+		}
+	}
+	return st.Code(), ""
+}
+
+func TestLedgerErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, debit, credit := newTestService(t)
+
+	limited := tb_types.Account{
+		ID:          tb_types.ID(),
+		UserData128: tb_types.ToUint128(1),
+		Ledger:      1,
+		Code:        1,
+		Flags:       tb_types.AccountFlags{DebitsMustNotExceedCredits: true}.ToUint16(),
+	}
+	_, err := repo.CreateAccount(ctx, limited)
+	require.NoError(t, err)
+	limitedID := tbutil.Uint128ToString(limited.ID)
+
+	tests := []struct {
+		name   string
+		req    *pb.CreateTransferRequest
+		code   codes.Code
+		reason string
+	}{
+		{
+			name:   "insufficient funds",
+			req:    &pb.CreateTransferRequest{DebitAccountId: limitedID, CreditAccountId: credit, Amount: "1", Ledger: 1, Code: "1"},
+			code:   codes.FailedPrecondition,
+			reason: "EXCEEDS_CREDITS",
+		},
+		{
+			name:   "missing account",
+			req:    &pb.CreateTransferRequest{DebitAccountId: debit, CreditAccountId: "424242", Amount: "1", Ledger: 1, Code: "1"},
+			code:   codes.NotFound,
+			reason: "CREDIT_ACCOUNT_NOT_FOUND",
+		},
+		{
+			name:   "different ledgers",
+			req:    &pb.CreateTransferRequest{DebitAccountId: debit, CreditAccountId: credit, Amount: "1", Ledger: 2, Code: "1"},
+			code:   codes.FailedPrecondition,
+			reason: "TRANSFER_MUST_HAVE_THE_SAME_LEDGER_AS_ACCOUNTS",
+		},
+		{
+			name: "flag misuse",
+			req: &pb.CreateTransferRequest{
+				PendingId: "1",
+				Flags:     uint32(tb_types.TransferFlags{PostPendingTransfer: true, VoidPendingTransfer: true}.ToUint16()),
+			},
+			code:   codes.InvalidArgument,
+			reason: "FLAGS_ARE_MUTUALLY_EXCLUSIVE",
+		},
+		{
+			name:   "rejected before reaching the ledger",
+			req:    &pb.CreateTransferRequest{DebitAccountId: debit, CreditAccountId: credit, Amount: "1", Code: "1"},
+			code:   codes.InvalidArgument,
+			reason: "VALIDATION_FAILED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateTransfer(ctx, tt.req)
+			code, reason := errorReason(t, err)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	t.Run("unknown pending transfer", func(t *testing.T) {
+		_, err := svc.VoidPending(ctx, &pb.VoidPendingRequest{PendingId: "424242"})
+		code, reason := errorReason(t, err)
+		assert.Equal(t, codes.NotFound, code)
+		assert.Equal(t, "PENDING_TRANSFER_NOT_FOUND", reason)
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		_, err := svc.GetAccount(ctx, &pb.GetAccountRequest{Id: "424242"})
+		code, reason := errorReason(t, err)
+		assert.Equal(t, codes.NotFound, code)
+		assert.Equal(t, "ACCOUNT_NOT_FOUND", reason)
+	})
+
+	t.Run("conflicting transfer ID", func(t *testing.T) {
+		req := &pb.CreateTransferRequest{DebitAccountId: debit, CreditAccountId: credit, Amount: "5", Ledger: 1, Code: "1", Id: "777"}
+		_, err := svc.CreateTransfer(ctx, req)
+		require.NoError(t, err)
+
+		req.Amount = "6"
+		_, err = svc.CreateTransfer(ctx, req)
+		code, reason := errorReason(t, err)
+		assert.Equal(t, codes.AlreadyExists, code)
+		assert.Equal(t, "EXISTS_WITH_DIFFERENT_AMOUNT", reason)
+	})
+}
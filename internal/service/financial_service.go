@@ -3,226 +3,406 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
 
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
 	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
 
 	. "github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
 	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
 	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
 
-	"google.golang.org/grpc/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/status"
 )
 
 // FinancialService implements the gRPC interface
 type FinancialService struct {
 	pb.UnimplementedFinancialServiceServer
-	repo *repository.TigerBeetleRepository
+	repo repository.Ledger
 }
 
 // NewFinancialService creates a new instance of the service
-func NewFinancialService(repo *repository.TigerBeetleRepository) *FinancialService {
+func NewFinancialService(repo repository.Ledger) *FinancialService {
 	return &FinancialService{
 		repo: repo,
 	}
 }
 
 // CreateAccount creates a new account
-func (s *FinancialService) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.AccountResponse, error) {
-	id := tb_types.ID()
+func (s *FinancialService) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (_ *pb.AccountResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.CreateAccount")
+	defer func() { tracing.End(span, err) }()
 
-	account := tb_types.Account{
-		ID:          id,
-		UserData128: tb_types.ToUint128(0),
-		UserData64:  0,
-		UserData32:  0,
-		Ledger:      req.Ledger,
-		Code:        uint16(req.Code),
-		Flags:       uint16(req.Flags),
-		Timestamp:   0,
-	}
-
-	_, err := s.repo.CreateAccount(ctx, account)
-	if err != nil {
+	fields := newFieldErrors()
+	account := accountFromRequest(fields, req)
+	if err := fields.err(); err != nil {
 		return &pb.AccountResponse{
 			Success:      false,
-			ErrorMessage: err.Error(),
-		}, status.Error(codes.Internal, err.Error())
+			ErrorMessage: status.Convert(err).Message(),
+		}, err
 	}
 
-	credits, err := Uint128ToUint64Safe(account.CreditsPosted)
-	if err != nil {
-		return nil, fmt.Errorf("error converting credits: %w", err)
-	}
+	span.SetAttributes(tracing.Ledger(account.Ledger), tracing.Code(account.Code))
 
-	debits, err := Uint128ToUint64Safe(account.DebitsPosted)
+	// An identical retry returns the account created by the first call
+	created, err := s.repo.CreateAccount(ctx, account)
 	if err != nil {
-		return nil, fmt.Errorf("error converting debits: %w", err)
-	}
-
-	balance := credits - debits
-	account_id := Uint128ToString(account.ID)
-
-	response := &pb.AccountResponse{
-		Id:       account_id,
-		Code:     uint32(account.Code),
-		Ledger:   account.Ledger,
-		Balance:  int64(balance),
-		Flags:    uint32(account.Flags),
-		UserData: "",
-		Success:  true,
+		return &pb.AccountResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, statusFromError(err)
 	}
 
-	return response, nil
+	return toAccountResponse(created), nil
 }
 
 // GetAccount fetches an account by ID
-func (s *FinancialService) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.AccountResponse, error) {
-	id, err := ParseUint128FromString(req.Id)
-	if err != nil {
-		log.Fatalf("Error converting ID: %v", err)
+func (s *FinancialService) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (_ *pb.AccountResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.GetAccount")
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	id := fields.id("id", req.Id)
+	if err := fields.err(); err != nil {
+		return &pb.AccountResponse{
+			Success:      false,
+			ErrorMessage: status.Convert(err).Message(),
+		}, err
 	}
 
 	account, err := s.repo.GetAccount(ctx, id)
 	if err != nil {
-		log.Printf("Error fetching account: %v", err)
+		logger.ErrorContext(ctx, "error fetching account", "error", err)
 		return &pb.AccountResponse{
 			Success:      false,
 			ErrorMessage: err.Error(),
-		}, status.Error(codes.Internal, err.Error())
+		}, statusFromError(err)
 	}
 
-	account_id := Uint128ToString(account.ID)
+	return toAccountResponse(account), nil
+}
 
-	credits, err := Uint128ToUint64Safe(account.CreditsPosted)
-	if err != nil {
-		return nil, fmt.Errorf("error converting credits: %w", err)
+// toAccountResponse maps an account to its response. Amounts are full
+// 128-bit decimals and the balance is signed, so a debit-heavy account
+// reports a negative balance instead of wrapping around.
+func toAccountResponse(account *tb_types.Account) *pb.AccountResponse {
+	return &pb.AccountResponse{
+		Id:             Uint128ToString(account.ID),
+		Code:           uint32(account.Code),
+		Ledger:         account.Ledger,
+		Flags:          uint32(account.Flags),
+		Balance:        DiffUint128(account.CreditsPosted, account.DebitsPosted).String(),
+		DebitsPending:  Uint128ToString(account.DebitsPending),
+		DebitsPosted:   Uint128ToString(account.DebitsPosted),
+		CreditsPending: Uint128ToString(account.CreditsPending),
+		CreditsPosted:  Uint128ToString(account.CreditsPosted),
+		UserData_128:   Uint128ToString(account.UserData128),
+		UserData_64:    account.UserData64,
+		UserData_32:    account.UserData32,
+		Success:        true,
 	}
+}
 
-	debits, err := Uint128ToUint64Safe(account.DebitsPosted)
-	if err != nil {
-		return nil, fmt.Errorf("error converting debits: %w", err)
+// CreateTransfer creates a new transfer
+func (s *FinancialService) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (_ *pb.TransferResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.CreateTransfer")
+	defer func() { tracing.End(span, err) }()
+
+	logger.DebugContext(ctx, "received request to create transfer",
+		"id", req.Id,
+		"debit_account_id", req.DebitAccountId,
+		"credit_account_id", req.CreditAccountId,
+		"pending_id", req.PendingId,
+		"ledger", req.Ledger,
+		"code", req.Code,
+		"flags", req.Flags,
+	)
+
+	fields := newFieldErrors()
+	transfer := transferFromRequest(fields, req)
+	if err := fields.err(); err != nil {
+		logger.InfoContext(ctx, "invalid transfer request", "error", err)
+		return &pb.TransferResponse{
+			Success:      false,
+			ErrorMessage: status.Convert(err).Message(),
+		}, err
 	}
+	span.SetAttributes(tracing.Ledger(transfer.Ledger), tracing.Code(transfer.Code))
 
-	balance := credits - debits
-
-	response := &pb.AccountResponse{
-		Id:      account_id,
-		Code:    uint32(account.Code),
-		Ledger:  account.Ledger,
-		Balance: int64(balance),
-		Flags:   uint32(account.Flags),
-		Success: true,
+	created, err := s.repo.CreateTransfer(ctx, transfer)
+	if err != nil {
+		logger.ErrorContext(ctx, "error creating transfer", "error", err)
+		return &pb.TransferResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, statusFromError(err)
 	}
 
-	return response, nil
+	return toTransferResponse(created), nil
 }
 
-// CreateTransfer creates a new transfer
-func (s *FinancialService) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.TransferResponse, error) {
-	log.Printf("Received request to create transfer: %+v", req)
+// GetTransfer fetches a transfer by ID
+func (s *FinancialService) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (_ *pb.TransferResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.GetTransfer")
+	defer func() { tracing.End(span, err) }()
 
-	code, err := strconv.ParseUint(req.Code, 10, 16)
-	if err != nil {
-		log.Printf("Invalid code: %v", err)
+	fields := newFieldErrors()
+	id := fields.id("id", req.Id)
+	if err := fields.err(); err != nil {
 		return &pb.TransferResponse{
 			Success:      false,
-			ErrorMessage: "Invalid code: " + err.Error(),
-		}, status.Error(codes.InvalidArgument, "Invalid code")
+			ErrorMessage: status.Convert(err).Message(),
+		}, err
 	}
 
-	debit_account_id, err := ParseUint128FromString(req.DebitAccountId)
+	transfer, err := s.repo.GetTransfer(ctx, id)
 	if err != nil {
-		log.Fatalf("Error converting ID: %v", err)
+		logger.ErrorContext(ctx, "error fetching transfer", "error", err)
+		return &pb.TransferResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, statusFromError(err)
 	}
 
-	credit_account_id, err := ParseUint128FromString(req.CreditAccountId)
-	if err != nil {
-		log.Fatalf("Error converting ID: %v", err)
-	}
+	return toTransferResponse(transfer), nil
+}
 
-	amount := tb_types.ToUint128(req.Amount)
+// ReserveFunds creates a pending transfer that holds funds until it is
+// captured, voided or times out
+func (s *FinancialService) ReserveFunds(ctx context.Context, req *pb.ReserveFundsRequest) (_ *pb.TransferResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.ReserveFunds")
+	defer func() { tracing.End(span, err) }()
 
+	fields := newFieldErrors()
 	transfer := tb_types.Transfer{
-		ID:              tb_types.ID(),
-		DebitAccountID:  debit_account_id,
-		CreditAccountID: credit_account_id,
+		ID:              fields.newID("id", req.Id),
+		DebitAccountID:  fields.id("debit_account_id", req.DebitAccountId),
+		CreditAccountID: fields.id("credit_account_id", req.CreditAccountId),
+		Amount:          fields.amount("amount", req.Amount),
+		UserData128:     fields.userData("user_data_128", req.UserData_128),
+		UserData64:      req.UserData_64,
+		UserData32:      req.UserData_32,
+		Timeout:         req.Timeout,
 		Ledger:          req.Ledger,
-		Code:            uint16(code),
-		Flags:           uint16(req.Flags),
-		Amount:          amount,
+		Code:            fields.code("code", req.Code, true),
+		Flags:           tb_types.TransferFlags{Pending: true}.ToUint16(),
+	}
+	if err := fields.err(); err != nil {
+		return &pb.TransferResponse{
+			Success:      false,
+			ErrorMessage: status.Convert(err).Message(),
+		}, err
 	}
+	span.SetAttributes(tracing.Ledger(transfer.Ledger), tracing.Code(transfer.Code))
 
 	created, err := s.repo.CreateTransfer(ctx, transfer)
 	if err != nil {
-		log.Printf("Error creating transfer: %v", err)
 		return &pb.TransferResponse{
 			Success:      false,
 			ErrorMessage: err.Error(),
-		}, status.Error(codes.Internal, err.Error())
+		}, statusFromError(err)
 	}
 
-	idResult := Uint128ToString(created.ID)
-	debitAccountId := Uint128ToString(created.DebitAccountID)
-	creditAccountId := Uint128ToString(created.CreditAccountID)
+	return toTransferResponse(created), nil
+}
 
-	amountResult, err := Uint128ToUint64Safe(created.Amount)
-	if err != nil {
-		return nil, fmt.Errorf("error converting Amount: %w", err)
+// CapturePending posts a pending transfer. A zero amount captures the full
+// reserved amount; a smaller amount captures part of it and releases the rest.
+func (s *FinancialService) CapturePending(ctx context.Context, req *pb.CapturePendingRequest) (_ *pb.TransferResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.CapturePending")
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	pendingId := fields.id("pending_id", req.PendingId)
+	id := fields.newID("id", req.Id)
+	amount := fields.amount("amount", req.Amount)
+	if err := fields.err(); err != nil {
+		return &pb.TransferResponse{
+			Success:      false,
+			ErrorMessage: status.Convert(err).Message(),
+		}, err
 	}
-
-	response := &pb.TransferResponse{
-		Id:              idResult,
-		DebitAccountId:  debitAccountId,
-		CreditAccountId: creditAccountId,
-		Ledger:          created.Ledger,
-		Amount:          amountResult,
-		Code:            strconv.Itoa(int(created.Code)),
-		Flags:           uint32(created.Flags),
-		Success:         true,
+	if amount == (tb_types.Uint128{}) {
+		amount = MaxUint128
 	}
 
-	return response, nil
+	return s.resolvePending(ctx, tb_types.Transfer{
+		ID:        id,
+		PendingID: pendingId,
+		Amount:    amount,
+		Flags:     tb_types.TransferFlags{PostPendingTransfer: true}.ToUint16(),
+	})
 }
 
-// GetTransfer fetches a transfer by ID
-func (s *FinancialService) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (*pb.TransferResponse, error) {
+// VoidPending cancels a pending transfer and releases the reserved funds
+func (s *FinancialService) VoidPending(ctx context.Context, req *pb.VoidPendingRequest) (_ *pb.TransferResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.VoidPending")
+	defer func() { tracing.End(span, err) }()
 
-	id, err := ParseUint128FromString(req.Id)
-	if err != nil {
-		log.Fatalf("Error converting ID: %v", err)
+	fields := newFieldErrors()
+	pendingId := fields.id("pending_id", req.PendingId)
+	id := fields.newID("id", req.Id)
+	if err := fields.err(); err != nil {
+		return &pb.TransferResponse{
+			Success:      false,
+			ErrorMessage: status.Convert(err).Message(),
+		}, err
 	}
 
-	transfer, err := s.repo.GetTransfer(ctx, id)
+	return s.resolvePending(ctx, tb_types.Transfer{
+		ID:        id,
+		PendingID: pendingId,
+		Flags:     tb_types.TransferFlags{VoidPendingTransfer: true}.ToUint16(),
+	})
+}
+
+// resolvePending submits a post or void transfer and returns it as stored by
+// the ledger, with the accounts, ledger, code and amount it inherited from
+// the pending transfer.
+func (s *FinancialService) resolvePending(ctx context.Context, transfer tb_types.Transfer) (*pb.TransferResponse, error) {
+	stored, err := s.repo.CreateTransfer(ctx, transfer)
 	if err != nil {
-		log.Printf("Error fetching transfer: %v", err)
 		return &pb.TransferResponse{
 			Success:      false,
 			ErrorMessage: err.Error(),
-		}, status.Error(codes.Internal, err.Error())
-	}
-
-	amountResult, err := Uint128ToUint64Safe(transfer.Amount)
-	if err != nil {
-		return nil, fmt.Errorf("error converting Amount: %w", err)
+		}, statusFromError(err)
 	}
 
-	idResult := Uint128ToString(transfer.ID)
-	debitAccountId := Uint128ToString(transfer.DebitAccountID)
-	creditAccountId := Uint128ToString(transfer.CreditAccountID)
+	return toTransferResponse(stored), nil
+}
 
-	response := &pb.TransferResponse{
-		Id:              idResult,
-		DebitAccountId:  debitAccountId,
-		CreditAccountId: creditAccountId,
+func toTransferResponse(transfer *tb_types.Transfer) *pb.TransferResponse {
+	return &pb.TransferResponse{
+		Id:              Uint128ToString(transfer.ID),
+		DebitAccountId:  Uint128ToString(transfer.DebitAccountID),
+		CreditAccountId: Uint128ToString(transfer.CreditAccountID),
 		Ledger:          transfer.Ledger,
-		Amount:          amountResult,
+		Amount:          Uint128ToString(transfer.Amount),
 		Code:            strconv.Itoa(int(transfer.Code)),
 		Flags:           uint32(transfer.Flags),
+		PendingId:       pendingIdString(transfer.PendingID),
+		Timeout:         transfer.Timeout,
+		Timestamp:       transfer.Timestamp,
+		UserData_128:    Uint128ToString(transfer.UserData128),
+		UserData_64:     transfer.UserData64,
+		UserData_32:     transfer.UserData32,
 		Success:         true,
 	}
+}
+
+// pendingIdString formats a pending ID, leaving it empty when unset
+func pendingIdString(id tb_types.Uint128) string {
+	if id == (tb_types.Uint128{}) {
+		return ""
+	}
+	return Uint128ToString(id)
+}
+
+// CreateLinkedTransfers applies a list of transfer legs atomically: either
+// every leg succeeds or none does. Per-leg results are always returned, and
+// failed_index points at the leg that broke the chain.
+func (s *FinancialService) CreateLinkedTransfers(ctx context.Context, req *pb.CreateLinkedTransfersRequest) (_ *pb.LinkedTransfersResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.CreateLinkedTransfers",
+		trace.WithAttributes(tracing.BatchSize(len(req.Legs))))
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	if len(req.Legs) == 0 {
+		fields.add("legs", "at least one leg is required")
+	}
+	if len(req.Legs) > repository.MaxBatchSize {
+		fields.add("legs", fmt.Sprintf("at most %d legs are allowed", repository.MaxBatchSize))
+	}
+
+	transfers := make([]tb_types.Transfer, len(req.Legs))
+	for i, leg := range req.Legs {
+		transfers[i] = legToTransfer(fields.at("legs", i), leg)
+	}
+	if err := fields.err(); err != nil {
+		return &pb.LinkedTransfersResponse{
+			Success:      false,
+			FailedIndex:  -1,
+			ErrorMessage: status.Convert(err).Message(),
+		}, err
+	}
+
+	results, err := s.repo.CreateLinkedTransfers(ctx, transfers)
+	if err != nil {
+		return &pb.LinkedTransfersResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, statusFromError(err)
+	}
+
+	response := &pb.LinkedTransfersResponse{
+		Results:     make([]*pb.TransferLegResult, len(results)),
+		Success:     true,
+		FailedIndex: -1,
+	}
+	for i, result := range results {
+		response.Results[i] = &pb.TransferLegResult{
+			Index:      uint32(i),
+			Id:         Uint128ToString(transfers[i].ID),
+			ResultCode: uint32(result),
+			Result:     TransferResultName(result),
+		}
+		if result == tb_types.TransferOK {
+			continue
+		}
+		response.Success = false
+		if result != tb_types.TransferLinkedEventFailed && response.FailedIndex < 0 {
+			response.FailedIndex = int32(i)
+			response.ErrorMessage = fmt.Sprintf("leg %d failed: %s", i, TransferResultName(result))
+		}
+	}
+
+	return response, nil
+}
+
+func legToTransfer(fields fieldErrors, leg *pb.TransferLeg) tb_types.Transfer {
+	return tb_types.Transfer{
+		ID:              fields.newID("id", leg.Id),
+		DebitAccountID:  fields.id("debit_account_id", leg.DebitAccountId),
+		CreditAccountID: fields.id("credit_account_id", leg.CreditAccountId),
+		Amount:          fields.amount("amount", leg.Amount),
+		UserData128:     fields.userData("user_data_128", leg.UserData_128),
+		UserData64:      leg.UserData_64,
+		UserData32:      leg.UserData_32,
+		Ledger:          leg.Ledger,
+		Code:            fields.code("code", leg.Code, true),
+		Flags:           fields.uint16("flags", leg.Flags),
+	}
+}
+
+// ListAccountTransfers pages through the transfers of an account. The page
+// token returned with each page resumes the listing after its last transfer.
+func (s *FinancialService) ListAccountTransfers(ctx context.Context, req *pb.ListAccountTransfersRequest) (_ *pb.ListAccountTransfersResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.ListAccountTransfers")
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	filter := tb_types.AccountFilter{
+		AccountID: fields.id("account_id", req.AccountId),
+		Flags:     filterFlags(req.Direction, req.Reversed),
+	}
+	page := pageRequest{req.TimestampMin, req.TimestampMax, req.Limit, req.Reversed, req.PageToken}
+	transfers, next, err := paginate(fields, page, func(timestampMin, timestampMax uint64, limit uint32) ([]tb_types.Transfer, error) {
+		filter.TimestampMin, filter.TimestampMax, filter.Limit = timestampMin, timestampMax, limit
+		return s.repo.GetAccountTransfers(ctx, filter)
+	}, transferTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListAccountTransfersResponse{
+		Transfers:     make([]*pb.TransferResponse, len(transfers)),
+		NextPageToken: next,
+	}
+	for i := range transfers {
+		response.Transfers[i] = toTransferResponse(&transfers[i])
+	}
 
 	return response, nil
 }
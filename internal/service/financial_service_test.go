@@ -0,0 +1,811 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/service"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestService returns a service backed by an in-memory ledger with two
+// accounts on ledger 1, identified by the returned decimal IDs.
+func newTestService(t *testing.T) (*service.FinancialService, *repository.TigerBeetleRepository, string, string) {
+	t.Helper()
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+
+	ids := make([]string, 2)
+	for i := range ids {
+		account := tb_types.Account{
+			ID:          tb_types.ID(),
+			UserData128: tb_types.ToUint128(1),
+			Ledger:      1,
+			Code:        1,
+		}
+		_, err := repo.CreateAccount(context.Background(), account)
+		require.NoError(t, err)
+		ids[i] = tbutil.Uint128ToString(account.ID)
+	}
+
+	return service.NewFinancialService(repo), repo, ids[0], ids[1]
+}
+
+func getAccount(t *testing.T, repo *repository.TigerBeetleRepository, id string) *tb_types.Account {
+	t.Helper()
+	parsed, err := tbutil.ParseUint128FromString(id)
+	require.NoError(t, err)
+	account, err := repo.GetAccount(context.Background(), parsed)
+	require.NoError(t, err)
+	return account
+}
+
+func TestTwoPhaseTransfers(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, debit, credit := newTestService(t)
+
+	reserve := func() *pb.TransferResponse {
+		resp, err := svc.ReserveFunds(ctx, &pb.ReserveFundsRequest{
+			DebitAccountId:  debit,
+			CreditAccountId: credit,
+			Amount:          "100",
+			Ledger:          1,
+			Code:            "1",
+			Timeout:         60,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("partial capture", func(t *testing.T) {
+		pending := reserve()
+		assert.Equal(t, uint32(60), pending.Timeout)
+
+		captured, err := svc.CapturePending(ctx, &pb.CapturePendingRequest{PendingId: pending.Id, Amount: "30"})
+		require.NoError(t, err)
+		assert.Equal(t, pending.Id, captured.PendingId)
+		assert.Equal(t, debit, captured.DebitAccountId)
+		assert.Equal(t, "30", captured.Amount)
+
+		_, err = svc.VoidPending(ctx, &pb.VoidPendingRequest{PendingId: pending.Id})
+		assert.Error(t, err)
+	})
+
+	t.Run("full capture", func(t *testing.T) {
+		pending := reserve()
+
+		captured, err := svc.CapturePending(ctx, &pb.CapturePendingRequest{PendingId: pending.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "100", captured.Amount)
+	})
+
+	t.Run("void", func(t *testing.T) {
+		pending := reserve()
+
+		voided, err := svc.VoidPending(ctx, &pb.VoidPendingRequest{PendingId: pending.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "100", voided.Amount)
+	})
+
+	account := getAccount(t, repo, debit)
+	assert.Equal(t, tb_types.Uint128{}, account.DebitsPending)
+	assert.Equal(t, tb_types.ToUint128(130), account.DebitsPosted)
+}
+
+func TestCapturePendingInvalidID(t *testing.T) {
+	svc, _, _, _ := newTestService(t)
+
+	_, err := svc.CapturePending(context.Background(), &pb.CapturePendingRequest{PendingId: "abc"})
+	assert.Error(t, err)
+}
+
+func TestCreateLinkedTransfers(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, merchant, platform := newTestService(t)
+
+	leg := func(debit, credit string, amount string) *pb.TransferLeg {
+		return &pb.TransferLeg{DebitAccountId: debit, CreditAccountId: credit, Amount: amount, Ledger: 1, Code: "1"}
+	}
+
+	t.Run("all legs applied", func(t *testing.T) {
+		resp, err := svc.CreateLinkedTransfers(ctx, &pb.CreateLinkedTransfersRequest{
+			Legs: []*pb.TransferLeg{leg(merchant, platform, "90"), leg(merchant, platform, "10")},
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.Success)
+		assert.Equal(t, int32(-1), resp.FailedIndex)
+		assert.Len(t, resp.Results, 2)
+	})
+
+	t.Run("broken chain reports the failing leg", func(t *testing.T) {
+		resp, err := svc.CreateLinkedTransfers(ctx, &pb.CreateLinkedTransfersRequest{
+			Legs: []*pb.TransferLeg{leg(merchant, platform, "90"), leg(merchant, merchant, "10"), leg(merchant, platform, "5")},
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.Success)
+		assert.Equal(t, int32(1), resp.FailedIndex)
+		assert.Equal(t, "linked_event_failed", resp.Results[0].Result)
+		assert.Equal(t, "accounts_must_be_different", resp.Results[1].Result)
+		assert.Equal(t, "linked_event_failed", resp.Results[2].Result)
+	})
+
+	t.Run("invalid leg is reported in the response", func(t *testing.T) {
+		resp, err := svc.CreateLinkedTransfers(ctx, &pb.CreateLinkedTransfersRequest{
+			Legs: []*pb.TransferLeg{leg(merchant, platform, "90"), leg(merchant, "x", "10")},
+		})
+		require.Error(t, err)
+		require.NotNil(t, resp)
+		assert.False(t, resp.Success)
+		assert.Equal(t, int32(-1), resp.FailedIndex)
+		assert.Contains(t, resp.ErrorMessage, "legs[1].credit_account_id")
+	})
+
+	account := getAccount(t, repo, merchant)
+	assert.Equal(t, tb_types.ToUint128(100), account.DebitsPosted)
+}
+
+func TestCreateTransfersBatch(t *testing.T) {
+	ctx := context.Background()
+	svc, _, debit, credit := newTestService(t)
+
+	transfer := func(debit, credit string) *pb.CreateTransferRequest {
+		return &pb.CreateTransferRequest{DebitAccountId: debit, CreditAccountId: credit, Amount: "10", Ledger: 1, Code: "1"}
+	}
+
+	resp, err := svc.CreateTransfersBatch(ctx, &pb.CreateTransfersBatchRequest{
+		Transfers: []*pb.CreateTransferRequest{
+			transfer(debit, credit),
+			transfer(debit, "12345"),
+			transfer(credit, debit),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), resp.Created)
+	assert.Equal(t, uint32(1), resp.Failed)
+	require.Len(t, resp.Results, 3)
+	assert.Equal(t, "ok", resp.Results[0].Result)
+	assert.Equal(t, uint32(1), resp.Results[1].Index)
+	assert.Equal(t, "credit_account_not_found", resp.Results[1].Result)
+
+	_, err = svc.CreateTransfersBatch(ctx, &pb.CreateTransfersBatchRequest{
+		Transfers: []*pb.CreateTransferRequest{transfer(debit, "abc")},
+	})
+	assert.Error(t, err)
+
+	// Replaying identical items is not a failure.
+	replay := transfer(debit, credit)
+	replay.Id = resp.Results[0].Id
+	resp, err = svc.CreateTransfersBatch(ctx, &pb.CreateTransfersBatchRequest{
+		Transfers: []*pb.CreateTransferRequest{replay},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, resp.Failed)
+	assert.Equal(t, uint32(1), resp.AlreadyExisted)
+	assert.Equal(t, "exists", resp.Results[0].Result)
+}
+
+// failingClient fails every CreateTransfers request after the first
+type failingClient struct {
+	*repository.MemoryClient
+	requests int
+}
+
+func (c *failingClient) CreateTransfers(transfers []tb_types.Transfer) ([]tb_types.TransferEventResult, error) {
+	c.requests++
+	if c.requests > 1 {
+		return nil, errors.New("connection lost")
+	}
+	return c.MemoryClient.CreateTransfers(transfers)
+}
+
+func TestCreateTransfersBatchPartiallyCommitted(t *testing.T) {
+	ctx := context.Background()
+	client := &failingClient{MemoryClient: repository.NewMemoryClient()}
+	_, err := client.CreateAccounts([]tb_types.Account{
+		{ID: tb_types.ToUint128(1), Ledger: 1, Code: 1},
+		{ID: tb_types.ToUint128(2), Ledger: 1, Code: 1},
+	})
+	require.NoError(t, err)
+	svc := service.NewFinancialService(repository.NewRepositoryWithClient(client))
+
+	transfers := make([]*pb.CreateTransferRequest, repository.MaxBatchSize+2)
+	for i := range transfers {
+		transfers[i] = &pb.CreateTransferRequest{DebitAccountId: "1", CreditAccountId: "2", Amount: "1", Ledger: 1, Code: "1"}
+	}
+	resp, err := svc.CreateTransfersBatch(ctx, &pb.CreateTransfersBatchRequest{Transfers: transfers})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(repository.MaxBatchSize), resp.Created)
+	assert.Equal(t, uint32(2), resp.NotAttempted)
+	assert.Contains(t, resp.ErrorMessage, "connection lost")
+	require.Len(t, resp.Results, len(transfers))
+
+	// The generated IDs of the committed items are reported, so they can be
+	// told apart from the ones to send again.
+	committed := resp.Results[repository.MaxBatchSize-1]
+	assert.Equal(t, "ok", committed.Result)
+	assert.NotEmpty(t, committed.Id)
+	last := resp.Results[len(transfers)-1]
+	assert.True(t, last.NotAttempted)
+	assert.Equal(t, "not_attempted", last.Result)
+
+	// A failure before anything was committed fails the call.
+	_, err = svc.CreateTransfersBatch(ctx, &pb.CreateTransfersBatchRequest{Transfers: transfers[:1]})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestCreateTransferIdempotentID(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, debit, credit := newTestService(t)
+
+	req := &pb.CreateTransferRequest{
+		Id:              "0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59",
+		DebitAccountId:  debit,
+		CreditAccountId: credit,
+		Amount:          "25",
+		Ledger:          1,
+		Code:            "1",
+	}
+
+	first, err := svc.CreateTransfer(ctx, req)
+	require.NoError(t, err)
+
+	replay, err := svc.CreateTransfer(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, first.Id, replay.Id)
+
+	account := getAccount(t, repo, debit)
+	assert.Equal(t, tb_types.ToUint128(25), account.DebitsPosted)
+
+	req.Amount = "26"
+	_, err = svc.CreateTransfer(ctx, req)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestListAccountTransfers(t *testing.T) {
+	ctx := context.Background()
+	svc, _, debit, credit := newTestService(t)
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		from, to := debit, credit
+		if i%2 == 1 {
+			from, to = credit, debit
+		}
+		resp, err := svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+			DebitAccountId: from, CreditAccountId: to, Amount: strconv.Itoa(i + 1), Ledger: 1, Code: "1",
+		})
+		require.NoError(t, err)
+		ids = append(ids, resp.Id)
+	}
+
+	list := func(req *pb.ListAccountTransfersRequest) []string {
+		var got []string
+		for {
+			resp, err := svc.ListAccountTransfers(ctx, req)
+			require.NoError(t, err)
+			for _, transfer := range resp.Transfers {
+				got = append(got, transfer.Id)
+			}
+			if resp.NextPageToken == "" {
+				return got
+			}
+			req.PageToken = resp.NextPageToken
+		}
+	}
+
+	t.Run("pages in order", func(t *testing.T) {
+		got := list(&pb.ListAccountTransfersRequest{AccountId: debit, Limit: 2})
+		assert.Equal(t, ids, got)
+	})
+
+	t.Run("reversed", func(t *testing.T) {
+		got := list(&pb.ListAccountTransfersRequest{AccountId: debit, Limit: 2, Reversed: true})
+		assert.Equal(t, []string{ids[4], ids[3], ids[2], ids[1], ids[0]}, got)
+	})
+
+	t.Run("debits only", func(t *testing.T) {
+		got := list(&pb.ListAccountTransfersRequest{
+			AccountId: debit,
+			Direction: pb.TransferDirection_TRANSFER_DIRECTION_DEBITS,
+		})
+		assert.Equal(t, []string{ids[0], ids[2], ids[4]}, got)
+	})
+
+	t.Run("malformed page token", func(t *testing.T) {
+		_, err := svc.ListAccountTransfers(ctx, &pb.ListAccountTransfersRequest{AccountId: debit, PageToken: "!"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("page token outside the range", func(t *testing.T) {
+		for _, req := range []*pb.ListAccountTransfersRequest{
+			{PageToken: pageToken(1), Reversed: true},
+			{PageToken: pageToken(0), Reversed: true},
+			{PageToken: pageToken(math.MaxUint64)},
+			{PageToken: pageToken(math.MaxUint64 - 1)},
+			{PageToken: pageToken(100), TimestampMax: 100},
+			{PageToken: pageToken(100), TimestampMin: 100, Reversed: true},
+			{PageToken: pageToken(50), TimestampMin: 100, TimestampMax: 200},
+			{PageToken: pageToken(300), TimestampMin: 100, TimestampMax: 200, Reversed: true},
+		} {
+			req.AccountId = debit
+			_, err := svc.ListAccountTransfers(ctx, req)
+			assert.Equal(t, []string{"page_token"}, violatedFields(t, err), "%v", req)
+		}
+	})
+
+	t.Run("no token past the end of the range", func(t *testing.T) {
+		last, err := svc.GetTransfer(ctx, &pb.GetTransferRequest{Id: ids[1]})
+		require.NoError(t, err)
+
+		resp, err := svc.ListAccountTransfers(ctx, &pb.ListAccountTransfersRequest{
+			AccountId: debit, Limit: 2, TimestampMax: last.Timestamp,
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.Transfers, 2)
+		assert.Empty(t, resp.NextPageToken)
+	})
+}
+
+// pageToken crafts the token of a page ending at timestamp
+func pageToken(timestamp uint64) string {
+	return base64.RawURLEncoding.EncodeToString(binary.BigEndian.AppendUint64(nil, timestamp))
+}
+
+func TestAccountBalances(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, debit, _ := newTestService(t)
+
+	history := tb_types.Account{
+		ID:          tb_types.ID(),
+		UserData128: tb_types.ToUint128(1),
+		Ledger:      1,
+		Code:        1,
+		Flags:       tb_types.AccountFlags{History: true}.ToUint16(),
+	}
+	_, err := repo.CreateAccount(ctx, history)
+	require.NoError(t, err)
+	historyID := tbutil.Uint128ToString(history.ID)
+
+	var timestamps []uint64
+	for _, amount := range []string{"10", "20", "30"} {
+		resp, err := svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+			DebitAccountId: debit, CreditAccountId: historyID, Amount: amount, Ledger: 1, Code: "1",
+		})
+		require.NoError(t, err)
+		transfer, err := svc.GetTransfer(ctx, &pb.GetTransferRequest{Id: resp.Id})
+		require.NoError(t, err)
+		timestamps = append(timestamps, transfer.Timestamp)
+	}
+
+	t.Run("pages through history", func(t *testing.T) {
+		first, err := svc.GetAccountBalances(ctx, &pb.GetAccountBalancesRequest{AccountId: historyID, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first.Balances, 2)
+		assert.Equal(t, "10", first.Balances[0].CreditsPosted)
+		assert.Equal(t, "30", first.Balances[1].CreditsPosted)
+		require.NotEmpty(t, first.NextPageToken)
+
+		second, err := svc.GetAccountBalances(ctx, &pb.GetAccountBalancesRequest{
+			AccountId: historyID, Limit: 2, PageToken: first.NextPageToken,
+		})
+		require.NoError(t, err)
+		require.Len(t, second.Balances, 1)
+		assert.Equal(t, "60", second.Balances[0].CreditsPosted)
+		assert.Equal(t, timestamps[2], second.Balances[0].Timestamp)
+		assert.Empty(t, second.NextPageToken)
+	})
+
+	t.Run("balance at timestamp", func(t *testing.T) {
+		balance, err := svc.GetBalanceAt(ctx, &pb.GetBalanceAtRequest{AccountId: historyID, Timestamp: timestamps[1]})
+		require.NoError(t, err)
+		assert.Equal(t, "30", balance.CreditsPosted)
+
+		balance, err = svc.GetBalanceAt(ctx, &pb.GetBalanceAtRequest{AccountId: historyID, Timestamp: timestamps[0] - 1})
+		require.NoError(t, err)
+		assert.Equal(t, "0", balance.CreditsPosted)
+		assert.Zero(t, balance.Timestamp)
+	})
+
+	t.Run("account without history", func(t *testing.T) {
+		_, err := svc.GetAccountBalances(ctx, &pb.GetAccountBalancesRequest{AccountId: debit})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		_, err := svc.GetBalanceAt(ctx, &pb.GetBalanceAtRequest{AccountId: "12345", Timestamp: 1})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestAccountAmounts(t *testing.T) {
+	ctx := context.Background()
+	svc, _, debit, credit := newTestService(t)
+
+	// Larger than a uint64 can hold
+	const large = "36893488147419103232"
+	transfer, err := svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+		DebitAccountId: debit, CreditAccountId: credit, Amount: large, Ledger: 1, Code: "1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, large, transfer.Amount)
+
+	debitAccount, err := svc.GetAccount(ctx, &pb.GetAccountRequest{Id: debit})
+	require.NoError(t, err)
+	assert.Equal(t, large, debitAccount.DebitsPosted)
+	assert.Equal(t, "0", debitAccount.CreditsPosted)
+	assert.Equal(t, "-"+large, debitAccount.Balance)
+
+	creditAccount, err := svc.GetAccount(ctx, &pb.GetAccountRequest{Id: credit})
+	require.NoError(t, err)
+	assert.Equal(t, large, creditAccount.Balance)
+
+	t.Run("invalid amount", func(t *testing.T) {
+		_, err := svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+			DebitAccountId: debit, CreditAccountId: credit, Amount: "-5", Ledger: 1, Code: "1",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestUserData(t *testing.T) {
+	ctx := context.Background()
+	svc, _, debit, credit := newTestService(t)
+
+	t.Run("account", func(t *testing.T) {
+		const customer = "0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59"
+		created, err := svc.CreateAccount(ctx, &pb.CreateAccountRequest{
+			Ledger: 1, Code: 1, UserData_128: customer, UserData_64: 64, UserData_32: 32,
+		})
+		require.NoError(t, err)
+
+		account, err := svc.GetAccount(ctx, &pb.GetAccountRequest{Id: created.Id})
+		require.NoError(t, err)
+		userData, err := tbutil.UserDataFromUUID(customer)
+		require.NoError(t, err)
+		assert.Equal(t, tbutil.Uint128ToString(userData), account.UserData_128)
+		assert.Equal(t, uint64(64), account.UserData_64)
+		assert.Equal(t, uint32(32), account.UserData_32)
+	})
+
+	t.Run("account without user data", func(t *testing.T) {
+		account, err := svc.CreateAccount(ctx, &pb.CreateAccountRequest{Ledger: 1, Code: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "0", account.UserData_128)
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		created, err := svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+			DebitAccountId: debit, CreditAccountId: credit, Amount: "1", Ledger: 1, Code: "1",
+			UserData_128: "42", UserData_64: 7, UserData_32: 3,
+		})
+		require.NoError(t, err)
+
+		transfer, err := svc.GetTransfer(ctx, &pb.GetTransferRequest{Id: created.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "42", transfer.UserData_128)
+		assert.Equal(t, uint64(7), transfer.UserData_64)
+		assert.Equal(t, uint32(3), transfer.UserData_32)
+	})
+
+	t.Run("invalid user data", func(t *testing.T) {
+		_, err := svc.CreateAccount(ctx, &pb.CreateAccountRequest{Ledger: 1, Code: 1, UserData_128: "customer-1"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	svc, _, debit, credit := newTestService(t)
+
+	const customer = "0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59"
+	var accounts []string
+	for i := 0; i < 3; i++ {
+		account, err := svc.CreateAccount(ctx, &pb.CreateAccountRequest{Ledger: 1, Code: 2, UserData_128: customer})
+		require.NoError(t, err)
+		accounts = append(accounts, account.Id)
+	}
+
+	var refunds []string
+	for i := 0; i < 4; i++ {
+		code := "1"
+		if i%2 == 0 {
+			code = "7"
+		}
+		transfer, err := svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+			DebitAccountId: debit, CreditAccountId: credit, Amount: "1", Ledger: 1, Code: code,
+		})
+		require.NoError(t, err)
+		if code == "7" {
+			refunds = append(refunds, transfer.Id)
+		}
+	}
+
+	t.Run("accounts of a customer, paged", func(t *testing.T) {
+		req := &pb.QueryAccountsRequest{UserData_128: customer, Limit: 2}
+		var got []string
+		for {
+			resp, err := svc.QueryAccounts(ctx, req)
+			require.NoError(t, err)
+			for _, account := range resp.Accounts {
+				got = append(got, account.Id)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		assert.Equal(t, accounts, got)
+	})
+
+	t.Run("transfers by code, reversed", func(t *testing.T) {
+		resp, err := svc.QueryTransfers(ctx, &pb.QueryTransfersRequest{Ledger: 1, Code: 7, Reversed: true})
+		require.NoError(t, err)
+		var got []string
+		for _, transfer := range resp.Transfers {
+			got = append(got, transfer.Id)
+		}
+		assert.Equal(t, []string{refunds[1], refunds[0]}, got)
+		assert.Empty(t, resp.NextPageToken)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := svc.QueryTransfers(ctx, &pb.QueryTransfersRequest{Code: 70000})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// importStream sends records to a client-streaming RPC and keeps its summary
+type importStream[T any] struct {
+	grpc.ServerStream
+	records []*T
+	summary *pb.ImportSummary
+}
+
+func (s *importStream[T]) Context() context.Context {
+	return context.Background()
+}
+
+func (s *importStream[T]) Recv() (*T, error) {
+	if len(s.records) == 0 {
+		return nil, io.EOF
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil
+}
+
+func (s *importStream[T]) SendAndClose(summary *pb.ImportSummary) error {
+	s.summary = summary
+	return nil
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+	svc := service.NewFinancialService(repo)
+
+	base := uint64(time.Now().Add(-time.Hour).UnixNano())
+	account := func(id string, timestamp uint64) *pb.ImportAccountRecord {
+		return &pb.ImportAccountRecord{
+			Account:   &pb.CreateAccountRequest{Id: id, Ledger: 1, Code: 1, UserData_128: "1"},
+			Timestamp: timestamp,
+		}
+	}
+	accounts := []*pb.ImportAccountRecord{
+		account("11", base+1),
+		account("12", base+2),
+		account("13", base+2),
+		{Timestamp: base + 3},
+		account("", base+4),
+	}
+
+	stream := &importStream[pb.ImportAccountRecord]{records: accounts}
+	require.NoError(t, svc.ImportAccounts(stream))
+	summary := stream.summary
+	assert.Equal(t, uint64(5), summary.Received)
+	assert.Equal(t, uint64(2), summary.Imported)
+	assert.Equal(t, uint64(3), summary.Failed)
+	assert.Equal(t, base+2, summary.LastCommittedTimestamp)
+	require.Len(t, summary.Failures, 3)
+	assert.Equal(t, uint64(2), summary.Failures[0].Index)
+	assert.Equal(t, "13", summary.Failures[0].Id)
+	assert.Equal(t, "TIMESTAMP_NOT_INCREASING", summary.Failures[0].Reason)
+	assert.Equal(t, "VALIDATION_FAILED", summary.Failures[1].Reason)
+	assert.Equal(t, "VALIDATION_FAILED", summary.Failures[2].Reason)
+
+	imported := getAccount(t, repo, "12")
+	assert.Equal(t, base+2, imported.Timestamp)
+	assert.True(t, imported.AccountFlags().Imported)
+
+	t.Run("resuming after the checkpoint", func(t *testing.T) {
+		checkpoint, err := svc.GetImportCheckpoint(ctx, &pb.GetImportCheckpointRequest{Kind: pb.ImportKind_IMPORT_KIND_ACCOUNTS})
+		require.NoError(t, err)
+		assert.Equal(t, base+2, checkpoint.Timestamp)
+		assert.Equal(t, base+2, checkpoint.NewestTimestamp)
+
+		// Records up to the checkpoint were committed and are reported as such
+		stream := &importStream[pb.ImportAccountRecord]{records: []*pb.ImportAccountRecord{
+			account("11", base+1),
+			account("12", base+2),
+			account("13", base+3),
+		}}
+		require.NoError(t, svc.ImportAccounts(stream))
+		assert.Equal(t, uint64(2), stream.summary.AlreadyImported)
+		assert.Equal(t, uint64(1), stream.summary.Imported)
+		assert.Zero(t, stream.summary.Failed)
+		assert.Equal(t, base+3, stream.summary.LastCommittedTimestamp)
+	})
+
+	t.Run("transfers", func(t *testing.T) {
+		transfer := func(id string, timestamp uint64) *pb.ImportTransferRecord {
+			return &pb.ImportTransferRecord{
+				Transfer: &pb.CreateTransferRequest{
+					Id: id, DebitAccountId: "11", CreditAccountId: "12", Amount: "5", Ledger: 1, Code: "1",
+				},
+				Timestamp: timestamp,
+			}
+		}
+		stream := &importStream[pb.ImportTransferRecord]{records: []*pb.ImportTransferRecord{
+			transfer("21", base+10),
+			transfer("22", base+11),
+			transfer("23", uint64(time.Now().Add(time.Hour).UnixNano())),
+		}}
+		require.NoError(t, svc.ImportTransfers(stream))
+		assert.Equal(t, uint64(2), stream.summary.Imported)
+		require.Len(t, stream.summary.Failures, 1)
+		assert.Equal(t, "IMPORTED_EVENT_TIMESTAMP_MUST_NOT_ADVANCE", stream.summary.Failures[0].Reason)
+		assert.Equal(t, "10", tbutil.Uint128ToString(getAccount(t, repo, "11").DebitsPosted))
+
+		checkpoint, err := svc.GetImportCheckpoint(ctx, &pb.GetImportCheckpointRequest{Kind: pb.ImportKind_IMPORT_KIND_TRANSFERS})
+		require.NoError(t, err)
+		assert.Equal(t, base+11, checkpoint.Timestamp)
+	})
+
+	t.Run("checkpoint reports live records", func(t *testing.T) {
+		live, err := svc.CreateAccount(ctx, &pb.CreateAccountRequest{Ledger: 1, Code: 1})
+		require.NoError(t, err)
+		liveTransfer, err := svc.CreateTransfer(ctx, &pb.CreateTransferRequest{
+			DebitAccountId: "11", CreditAccountId: "12", Amount: "1", Ledger: 1, Code: "1",
+		})
+		require.NoError(t, err)
+
+		checkpoint, err := svc.GetImportCheckpoint(ctx, &pb.GetImportCheckpointRequest{Kind: pb.ImportKind_IMPORT_KIND_ACCOUNTS})
+		require.NoError(t, err)
+		assert.Equal(t, base+3, checkpoint.Timestamp)
+		assert.Equal(t, getAccount(t, repo, live.Id).Timestamp, checkpoint.NewestTimestamp)
+
+		checkpoint, err = svc.GetImportCheckpoint(ctx, &pb.GetImportCheckpointRequest{Kind: pb.ImportKind_IMPORT_KIND_TRANSFERS})
+		require.NoError(t, err)
+		assert.Equal(t, base+11, checkpoint.Timestamp)
+		assert.Equal(t, liveTransfer.Timestamp, checkpoint.NewestTimestamp)
+
+		// Resuming after the checkpoint fails: the live account is newer
+		stream := &importStream[pb.ImportAccountRecord]{records: []*pb.ImportAccountRecord{
+			account("14", base+4),
+		}}
+		require.NoError(t, svc.ImportAccounts(stream))
+		require.Len(t, stream.summary.Failures, 1)
+		assert.Equal(t, "IMPORTED_EVENT_TIMESTAMP_MUST_NOT_REGRESS", stream.summary.Failures[0].Reason)
+	})
+
+	t.Run("checkpoint kind is required", func(t *testing.T) {
+		_, err := svc.GetImportCheckpoint(ctx, &pb.GetImportCheckpointRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestImportLinkedChains(t *testing.T) {
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+	svc := service.NewFinancialService(repo)
+
+	base := uint64(time.Now().Add(-time.Hour).UnixNano())
+	account := func(id string, timestamp uint64, linked bool) *pb.ImportAccountRecord {
+		record := &pb.ImportAccountRecord{
+			Account:   &pb.CreateAccountRequest{Id: id, Ledger: 1, Code: 1, UserData_128: "1"},
+			Timestamp: timestamp,
+		}
+		if linked {
+			record.Account.Flags = uint32(tb_types.AccountFlags{Linked: true}.ToUint16())
+		}
+		return record
+	}
+	exists := func(id string) bool {
+		parsed, err := tbutil.ParseUint128FromString(id)
+		require.NoError(t, err)
+		_, err = repo.GetAccount(context.Background(), parsed)
+		return err == nil
+	}
+
+	t.Run("a rejected record fails its whole chain", func(t *testing.T) {
+		stream := &importStream[pb.ImportAccountRecord]{records: []*pb.ImportAccountRecord{
+			account("31", base+1, true),
+			account("32", base+2, false),
+			account("33", base+3, true),
+			account("34", base+3, true),
+			account("35", base+5, false),
+			account("36", base+6, false),
+		}}
+		require.NoError(t, svc.ImportAccounts(stream))
+		summary := stream.summary
+		assert.Equal(t, uint64(3), summary.Imported)
+		assert.Equal(t, uint64(3), summary.Failed)
+		var reasons []string
+		for _, failure := range summary.Failures {
+			reasons = append(reasons, failure.Id+":"+failure.Reason)
+		}
+		assert.Equal(t, []string{
+			"33:LINKED_EVENT_FAILED",
+			"34:TIMESTAMP_NOT_INCREASING",
+			"35:LINKED_EVENT_FAILED",
+		}, reasons)
+
+		assert.True(t, exists("32"))
+		assert.False(t, exists("33"))
+		assert.False(t, exists("35"))
+		assert.True(t, exists("36"))
+	})
+
+	t.Run("a stream ending inside a chain is rejected", func(t *testing.T) {
+		stream := &importStream[pb.ImportAccountRecord]{records: []*pb.ImportAccountRecord{
+			account("37", base+7, false),
+			account("38", base+8, true),
+		}}
+		err := svc.ImportAccounts(stream)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "starting at record 1")
+
+		assert.True(t, exists("37"))
+		assert.False(t, exists("38"))
+	})
+}
+
+func TestImportCheckpointScanLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+	svc := service.NewFinancialService(repo)
+
+	stream := &importStream[pb.ImportAccountRecord]{records: []*pb.ImportAccountRecord{{
+		Account:   &pb.CreateAccountRequest{Id: "1", Ledger: 1, Code: 1},
+		Timestamp: uint64(time.Now().Add(-time.Hour).UnixNano()),
+	}}}
+	require.NoError(t, svc.ImportAccounts(stream))
+
+	request := &pb.GetImportCheckpointRequest{Kind: pb.ImportKind_IMPORT_KIND_ACCOUNTS}
+	checkpoint, err := svc.GetImportCheckpoint(ctx, request)
+	require.NoError(t, err)
+	assert.NotZero(t, checkpoint.Timestamp)
+	assert.False(t, checkpoint.ScanLimitReached)
+
+	// Bury the imported account under more live accounts than are scanned
+	live := make([]tb_types.Account, 4*repository.MaxBatchSize)
+	for i := range live {
+		live[i] = tb_types.Account{ID: tb_types.ToUint128(uint64(i + 2)), Ledger: 1, Code: 1}
+	}
+	_, err = repo.CreateAccounts(ctx, live)
+	require.NoError(t, err)
+
+	checkpoint, err = svc.GetImportCheckpoint(ctx, request)
+	require.NoError(t, err)
+	assert.True(t, checkpoint.ScanLimitReached)
+	assert.Zero(t, checkpoint.Timestamp)
+	assert.Equal(t, uint64(len(live)), checkpoint.Scanned)
+	assert.Equal(t, getAccount(t, repo, strconv.Itoa(len(live)+1)).Timestamp, checkpoint.NewestTimestamp)
+}
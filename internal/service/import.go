@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/validation"
+
+	. "github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// importBatchSize is how many records an import buffers before sending
+	// them to the ledger
+	importBatchSize = 1000
+	// maxImportFailures bounds the failures listed in an import summary
+	maxImportFailures = 1000
+	// checkpointScanPages bounds the pages of MaxBatchSize records that
+	// GetImportCheckpoint reads looking for an imported record
+	checkpointScanPages = 4
+
+	reasonTimestampNotIncreasing = "TIMESTAMP_NOT_INCREASING"
+	// Chain-level reasons, named like the ledger results for the same cases
+	reasonLinkedEventFailed    = "LINKED_EVENT_FAILED"
+	reasonLinkedEventChainOpen = "LINKED_EVENT_CHAIN_OPEN"
+)
+
+// ImportAccounts creates the streamed accounts with their original
+// timestamps. Records are sent to the ledger in batches; a record rejected
+// by the service or the ledger is listed in the summary and the import goes
+// on. A rejected record fails the rest of its linked chain with it, and a
+// stream that ends inside a chain is rejected. If the stream breaks, the
+// batches already sent stay committed and GetImportCheckpoint tells where to
+// resume.
+func (s *FinancialService) ImportAccounts(stream pb.FinancialService_ImportAccountsServer) (err error) {
+	ctx, span := tracer.Start(stream.Context(), "FinancialService.ImportAccounts")
+	defer func() { tracing.End(span, err) }()
+
+	summary, err := importRecords(ctx, importSpec[pb.CreateAccountRequest, tb_types.Account, tb_types.CreateAccountResult]{
+		kind:    "accounts",
+		missing: "account is required",
+		linked:  uint32(tb_types.AccountFlags{Linked: true}.ToUint16()),
+		flags:   (*pb.CreateAccountRequest).GetFlags,
+		id:      (*pb.CreateAccountRequest).GetId,
+		build: func(fields fieldErrors, req *pb.CreateAccountRequest, timestamp uint64) tb_types.Account {
+			account := accountFromRequest(fields, req)
+			account.Flags |= tb_types.AccountFlags{Imported: true}.ToUint16()
+			account.Timestamp = timestamp
+			return account
+		},
+		validate: validation.ValidateAccount,
+		create:   s.repo.CreateAccounts,
+		ok:       tb_types.AccountOK,
+		exists:   tb_types.AccountExists,
+		name:     AccountResultName,
+	}, func() (*pb.CreateAccountRequest, uint64, error) {
+		record, err := stream.Recv()
+		return record.GetAccount(), record.GetTimestamp(), err
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(summary)
+}
+
+// ImportTransfers creates the streamed transfers with their original
+// timestamps, like ImportAccounts.
+func (s *FinancialService) ImportTransfers(stream pb.FinancialService_ImportTransfersServer) (err error) {
+	ctx, span := tracer.Start(stream.Context(), "FinancialService.ImportTransfers")
+	defer func() { tracing.End(span, err) }()
+
+	summary, err := importRecords(ctx, importSpec[pb.CreateTransferRequest, tb_types.Transfer, tb_types.CreateTransferResult]{
+		kind:    "transfers",
+		missing: "transfer is required",
+		linked:  uint32(tb_types.TransferFlags{Linked: true}.ToUint16()),
+		flags:   (*pb.CreateTransferRequest).GetFlags,
+		id:      (*pb.CreateTransferRequest).GetId,
+		build: func(fields fieldErrors, req *pb.CreateTransferRequest, timestamp uint64) tb_types.Transfer {
+			transfer := transferFromRequest(fields, req)
+			transfer.Flags |= tb_types.TransferFlags{Imported: true}.ToUint16()
+			transfer.Timestamp = timestamp
+			return transfer
+		},
+		validate: validation.ValidateTransfer,
+		create:   s.repo.CreateTransfers,
+		ok:       tb_types.TransferOK,
+		exists:   tb_types.TransferExists,
+		name:     TransferResultName,
+	}, func() (*pb.CreateTransferRequest, uint64, error) {
+		record, err := stream.Recv()
+		return record.GetTransfer(), record.GetTimestamp(), err
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(summary)
+}
+
+// importSpec describes how to import one kind of record: Q is the request
+// message of a record, T the ledger object built from it and R the ledger
+// result of creating it.
+type importSpec[Q any, T any, R comparable] struct {
+	// kind names the records in logs
+	kind string
+	// missing rejects a record that carries no request
+	missing string
+	// linked is the flag that links a record to the next one
+	linked uint32
+	flags  func(*Q) uint32
+	id     func(*Q) string
+	// build decodes a request into an imported object with the timestamp
+	build    func(fieldErrors, *Q, uint64) T
+	validate func(T) error
+	create   func(context.Context, []T) ([]R, error)
+	ok       R
+	exists   R
+	name     func(R) string
+}
+
+// importRecords receives records until recv returns io.EOF, sending them to
+// the ledger in batches of importBatchSize, and returns the summary of the
+// import.
+func importRecords[Q any, T any, R comparable](ctx context.Context, spec importSpec[Q, T, R], recv func() (*Q, uint64, error)) (*pb.ImportSummary, error) {
+	run := newImportRun(spec.kind)
+	var batch []T
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		// On error, results covers the requests committed before it
+		results, err := spec.create(ctx, batch)
+		settleImport(ctx, run, results, spec.ok, spec.exists, spec.name)
+		if err != nil {
+			return statusFromError(err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		req, timestamp, err := recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		linked := spec.flags(req)&spec.linked != 0
+		item := run.receive(spec.id(req), timestamp)
+		reject := func(message string) {
+			run.reject(item, linked, reasonValidationFailed, message)
+			batch = batch[:len(run.pending)]
+		}
+		if run.inBrokenChain(item, linked) {
+			continue
+		}
+		if req == nil {
+			reject(spec.missing)
+			continue
+		}
+
+		fields := newFieldErrors()
+		if spec.id(req) == "" {
+			fields.add("id", "is required to import")
+		}
+		record := spec.build(fields, req, timestamp)
+		if err := fields.err(); err != nil {
+			reject(status.Convert(err).Message())
+			continue
+		}
+		if err := spec.validate(record); err != nil {
+			reject(err.Error())
+			continue
+		}
+		if !run.accept(item, linked) {
+			batch = batch[:len(run.pending)]
+			continue
+		}
+
+		batch = append(batch, record)
+		if len(batch) >= importBatchSize && !linked {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// A chain left open is not sent; the records before it are
+	openErr := run.endOfStream()
+	batch = batch[:len(run.pending)]
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if openErr != nil {
+		return nil, openErr
+	}
+	return run.summary, nil
+}
+
+// GetImportCheckpoint returns the timestamp of the newest imported account
+// or transfer, and of the newest one of any origin. The ledger rejects an
+// imported record that is not newer than every stored record of its kind,
+// live ones included, with IMPORTED_EVENT_TIMESTAMP_MUST_NOT_REGRESS. An
+// interrupted import resumes with the first record after the checkpoint
+// only while the two timestamps are equal: once live traffic wrote a newer
+// record, the remaining records older than it cannot be imported. Only the
+// newest records are searched; past that, the checkpoint reports that the
+// limit was reached instead of scanning the whole ledger.
+func (s *FinancialService) GetImportCheckpoint(ctx context.Context, req *pb.GetImportCheckpointRequest) (_ *pb.ImportCheckpoint, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.GetImportCheckpoint")
+	defer func() { tracing.End(span, err) }()
+
+	var checkpoint *pb.ImportCheckpoint
+	switch req.Kind {
+	case pb.ImportKind_IMPORT_KIND_ACCOUNTS:
+		checkpoint, err = newestImported(ctx, s.repo.QueryAccounts, func(account tb_types.Account) (uint64, bool) {
+			return account.Timestamp, account.AccountFlags().Imported
+		})
+	case pb.ImportKind_IMPORT_KIND_TRANSFERS:
+		checkpoint, err = newestImported(ctx, s.repo.QueryTransfers, func(transfer tb_types.Transfer) (uint64, bool) {
+			return transfer.Timestamp, transfer.TransferFlags().Imported
+		})
+	default:
+		return nil, status.Error(codes.InvalidArgument, "kind must be IMPORT_KIND_ACCOUNTS or IMPORT_KIND_TRANSFERS")
+	}
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	return checkpoint, nil
+}
+
+// newestImported pages through the ledger from the newest record back,
+// reading at most checkpointScanPages pages, and returns the timestamp of
+// that record and of the first imported one; either is 0 if there is no such
+// record. If the scan stops at the limit, the checkpoint says so.
+func newestImported[T any](ctx context.Context, query func(context.Context, tb_types.QueryFilter) ([]T, error), inspect func(T) (timestamp uint64, imported bool)) (*pb.ImportCheckpoint, error) {
+	checkpoint := &pb.ImportCheckpoint{}
+	filter := tb_types.QueryFilter{
+		Limit: repository.MaxBatchSize,
+		Flags: tb_types.QueryFilterFlags{Reversed: true}.ToUint32(),
+	}
+	for pages := 0; ; pages++ {
+		if pages == checkpointScanPages {
+			checkpoint.ScanLimitReached = true
+			logger.WarnContext(ctx, "no imported record among the newest records", "scanned", checkpoint.Scanned)
+			return checkpoint, nil
+		}
+		page, err := query(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		var timestamp uint64
+		for _, record := range page {
+			var imported bool
+			timestamp, imported = inspect(record)
+			checkpoint.Scanned++
+			if checkpoint.NewestTimestamp == 0 {
+				checkpoint.NewestTimestamp = timestamp
+			}
+			if imported {
+				checkpoint.Timestamp = timestamp
+				return checkpoint, nil
+			}
+		}
+		if len(page) < int(filter.Limit) || timestamp <= 1 {
+			return checkpoint, nil
+		}
+		filter.TimestampMax = timestamp - 1
+	}
+}
+
+// importRun tracks the progress of one import stream
+type importRun struct {
+	kind    string
+	summary *pb.ImportSummary
+	// timestamp of the last record accepted, which the next must exceed
+	lastTimestamp uint64
+	// records sent with the next batch
+	pending []importItem
+	// position in pending of the first record of the linked chain being
+	// received, or -1
+	chainStart int
+	// set when a record of the chain being received was rejected, so the
+	// rest of the chain fails with it
+	chainBroken bool
+}
+
+type importItem struct {
+	index     uint64
+	id        string
+	timestamp uint64
+}
+
+func newImportRun(kind string) *importRun {
+	return &importRun{kind: kind, summary: &pb.ImportSummary{}, chainStart: -1}
+}
+
+func (r *importRun) receive(id string, timestamp uint64) importItem {
+	item := importItem{index: r.summary.Received, id: id, timestamp: timestamp}
+	r.summary.Received++
+	return item
+}
+
+// accept queues a record for the next batch if its timestamp follows the
+// previous record's, and rejects it otherwise
+func (r *importRun) accept(item importItem, linked bool) bool {
+	if item.timestamp <= r.lastTimestamp {
+		r.reject(item, linked, reasonTimestampNotIncreasing, "timestamp must be greater than the previous record's")
+		return false
+	}
+	r.lastTimestamp = item.timestamp
+	r.pending = append(r.pending, item)
+	if !linked {
+		r.chainStart = -1
+	} else if r.chainStart < 0 {
+		r.chainStart = len(r.pending) - 1
+	}
+	return true
+}
+
+// inBrokenChain fails a record that follows a rejected record of its linked
+// chain
+func (r *importRun) inBrokenChain(item importItem, linked bool) bool {
+	if !r.chainBroken {
+		return false
+	}
+	r.fail(item, reasonLinkedEventFailed, "an earlier record of its linked chain failed")
+	r.chainBroken = linked
+	return true
+}
+
+// reject fails a record refused by the service. The records of its linked
+// chain already queued are dequeued and fail too, and so will the ones still
+// to come; the caller trims its batch to len(r.pending).
+func (r *importRun) reject(item importItem, linked bool, reason, message string) {
+	r.dropChain(reasonLinkedEventFailed, "a later record of its linked chain failed")
+	r.fail(item, reason, message)
+	r.chainBroken = linked
+}
+
+// endOfStream dequeues a linked chain still open when the stream ended and
+// returns the error that rejects the stream, or nil
+func (r *importRun) endOfStream() error {
+	if r.chainStart < 0 && !r.chainBroken {
+		return nil
+	}
+	message := "the stream ended inside a linked chain"
+	if r.chainStart >= 0 {
+		message = fmt.Sprintf("the stream ended inside the linked chain starting at record %d", r.pending[r.chainStart].index)
+	}
+	r.dropChain(reasonLinkedEventChainOpen, "the stream ended before the linked chain was closed")
+	return withReason(codes.InvalidArgument, message+"; the records before it were sent", reasonLinkedEventChainOpen, nil)
+}
+
+// dropChain dequeues the records of the open linked chain, failing them
+func (r *importRun) dropChain(reason, message string) {
+	if r.chainStart < 0 {
+		return
+	}
+	for _, item := range r.pending[r.chainStart:] {
+		r.fail(item, reason, message)
+	}
+	r.pending = r.pending[:r.chainStart]
+	r.chainStart = -1
+}
+
+func (r *importRun) fail(item importItem, reason, message string) {
+	r.summary.Failed++
+	if len(r.summary.Failures) < maxImportFailures {
+		r.summary.Failures = append(r.summary.Failures, &pb.ImportFailure{
+			Index:     item.index,
+			Id:        item.id,
+			Timestamp: item.timestamp,
+			Reason:    reason,
+			Message:   message,
+		})
+	}
+}
+
+// settleImport records the ledger results of the pending records. Records
+// that already exist unchanged were committed by an earlier attempt.
+func settleImport[R comparable](ctx context.Context, r *importRun, results []R, ok, exists R, name func(R) string) {
+	for i, result := range results {
+		item := r.pending[i]
+		switch result {
+		case ok:
+			r.summary.Imported++
+		case exists:
+			r.summary.AlreadyImported++
+		default:
+			r.fail(item, strings.ToUpper(name(result)), name(result))
+			continue
+		}
+		r.summary.LastCommittedTimestamp = item.timestamp
+	}
+	r.pending = r.pending[:0]
+
+	logger.InfoContext(ctx, "import progress", "kind", r.kind,
+		"received", r.summary.Received, "imported", r.summary.Imported,
+		"already_imported", r.summary.AlreadyImported, "failed", r.summary.Failed,
+		"last_committed_timestamp", r.summary.LastCommittedTimestamp)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+)
+
+const defaultPageSize = 50
+
+// pageLimit applies the default page size and caps it at what the ledger can
+// return in a single request
+func pageLimit(limit uint32) uint32 {
+	if limit == 0 {
+		return defaultPageSize
+	}
+	return min(limit, repository.MaxBatchSize)
+}
+
+// encodePageToken builds an opaque cursor from the timestamp of the last item
+// of a page. Timestamps are unique, so the next page resumes right after it.
+func encodePageToken(timestamp uint64) string {
+	return base64.RawURLEncoding.EncodeToString(binary.BigEndian.AppendUint64(nil, timestamp))
+}
+
+func decodePageToken(token string) (uint64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) != 8 {
+		return 0, errors.New("malformed page token")
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// resumeAfter narrows the [timestampMin, timestampMax] range so that it
+// starts after the cursor, in the direction of the scan. It reports false,
+// leaving the range as is, if no timestamp of the range lies after the
+// cursor: the new bound would fall outside the range or reach 0 or
+// MaxUint64, which the ledger does not read as a bound.
+func resumeAfter(timestampMin, timestampMax *uint64, cursor uint64, reversed bool) bool {
+	var next uint64
+	if reversed {
+		if cursor <= 1 {
+			return false
+		}
+		next = cursor - 1
+	} else {
+		if cursor >= math.MaxUint64-1 {
+			return false
+		}
+		next = cursor + 1
+	}
+	if (*timestampMin != 0 && next < *timestampMin) || (*timestampMax != 0 && next > *timestampMax) {
+		return false
+	}
+
+	if reversed {
+		*timestampMax = next
+	} else {
+		*timestampMin = next
+	}
+	return true
+}
+
+// nextPageToken returns the token of the page after a full one ending at
+// last, or "" if the range has nothing after it
+func nextPageToken(timestampMin, timestampMax, last uint64, reversed bool) string {
+	if !resumeAfter(&timestampMin, &timestampMax, last, reversed) {
+		return ""
+	}
+	return encodePageToken(last)
+}
+
+// pageRequest is what a paginated request asks for
+type pageRequest struct {
+	timestampMin, timestampMax uint64
+	limit                      uint32
+	reversed                   bool
+	token                      string
+}
+
+// paginate fetches one page of a listing ordered by timestamp. It applies
+// the page size and narrows the range to resume after the page token, which
+// must lie inside it, then returns the page with the token of the next one,
+// or "" after the last. Invalid fields, collected in fields along with the
+// page token, are reported before anything is fetched.
+func paginate[T any](fields fieldErrors, req pageRequest, fetch func(timestampMin, timestampMax uint64, limit uint32) ([]T, error), timestamp func(*T) uint64) ([]T, string, error) {
+	timestampMin, timestampMax, limit := req.timestampMin, req.timestampMax, pageLimit(req.limit)
+	cursor, resume := fields.pageToken("page_token", req.token)
+	if resume && !resumeAfter(&timestampMin, &timestampMax, cursor, req.reversed) {
+		fields.add("page_token", "is outside the requested timestamp range")
+	}
+	if err := fields.err(); err != nil {
+		return nil, "", err
+	}
+
+	items, err := fetch(timestampMin, timestampMax, limit)
+	if err != nil {
+		return nil, "", statusFromError(err)
+	}
+
+	// A full page may be followed by more items
+	var next string
+	if len(items) == int(limit) {
+		next = nextPageToken(timestampMin, timestampMax, timestamp(&items[len(items)-1]), req.reversed)
+	}
+	return items, next, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// QueryAccounts pages through the accounts matching every non-zero field of
+// the request, e.g. all accounts of a customer by user_data_128
+func (s *FinancialService) QueryAccounts(ctx context.Context, req *pb.QueryAccountsRequest) (_ *pb.QueryAccountsResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.QueryAccounts")
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	filter := tb_types.QueryFilter{
+		UserData128: fields.userData("user_data_128", req.UserData_128),
+		UserData64:  req.UserData_64,
+		UserData32:  req.UserData_32,
+		Ledger:      req.Ledger,
+		Code:        fields.uint16("code", req.Code),
+		Flags:       tb_types.QueryFilterFlags{Reversed: req.Reversed}.ToUint32(),
+	}
+	page := pageRequest{req.TimestampMin, req.TimestampMax, req.Limit, req.Reversed, req.PageToken}
+	accounts, next, err := paginate(fields, page, func(timestampMin, timestampMax uint64, limit uint32) ([]tb_types.Account, error) {
+		filter.TimestampMin, filter.TimestampMax, filter.Limit = timestampMin, timestampMax, limit
+		return s.repo.QueryAccounts(ctx, filter)
+	}, accountTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.QueryAccountsResponse{
+		Accounts:      make([]*pb.AccountResponse, len(accounts)),
+		NextPageToken: next,
+	}
+	for i := range accounts {
+		response.Accounts[i] = toAccountResponse(&accounts[i])
+	}
+
+	return response, nil
+}
+
+// QueryTransfers pages through the transfers matching every non-zero field
+// of the request, e.g. all refunds of a ledger in a time range by code
+func (s *FinancialService) QueryTransfers(ctx context.Context, req *pb.QueryTransfersRequest) (_ *pb.QueryTransfersResponse, err error) {
+	ctx, span := tracer.Start(ctx, "FinancialService.QueryTransfers")
+	defer func() { tracing.End(span, err) }()
+
+	fields := newFieldErrors()
+	filter := tb_types.QueryFilter{
+		UserData128: fields.userData("user_data_128", req.UserData_128),
+		UserData64:  req.UserData_64,
+		UserData32:  req.UserData_32,
+		Ledger:      req.Ledger,
+		Code:        fields.uint16("code", req.Code),
+		Flags:       tb_types.QueryFilterFlags{Reversed: req.Reversed}.ToUint32(),
+	}
+	page := pageRequest{req.TimestampMin, req.TimestampMax, req.Limit, req.Reversed, req.PageToken}
+	transfers, next, err := paginate(fields, page, func(timestampMin, timestampMax uint64, limit uint32) ([]tb_types.Transfer, error) {
+		filter.TimestampMin, filter.TimestampMax, filter.Limit = timestampMin, timestampMax, limit
+		return s.repo.QueryTransfers(ctx, filter)
+	}, transferTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.QueryTransfersResponse{
+		Transfers:     make([]*pb.TransferResponse, len(transfers)),
+		NextPageToken: next,
+	}
+	for i := range transfers {
+		response.Transfers[i] = toTransferResponse(&transfers[i])
+	}
+
+	return response, nil
+}
+
+func accountTimestamp(account *tb_types.Account) uint64 { return account.Timestamp }
+
+func transferTimestamp(transfer *tb_types.Transfer) uint64 { return transfer.Timestamp }
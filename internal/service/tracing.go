@@ -0,0 +1,5 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("github.com/pauloaugusto-dmf/tigerbeetle-service/internal/service")
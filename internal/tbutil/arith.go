@@ -0,0 +1,142 @@
+package tbutil
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// MaxUint128 is the largest value representable by a Uint128 (2^128 - 1).
+var MaxUint128 = types.Uint128{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+func uint128Words(u types.Uint128) (lo, hi uint64) {
+	return binary.LittleEndian.Uint64(u[0:8]), binary.LittleEndian.Uint64(u[8:16])
+}
+
+func uint128FromWords(lo, hi uint64) types.Uint128 {
+	var u types.Uint128
+	binary.LittleEndian.PutUint64(u[0:8], lo)
+	binary.LittleEndian.PutUint64(u[8:16], hi)
+	return u
+}
+
+// AddUint128 returns a + b. The boolean result reports whether the sum overflowed.
+func AddUint128(a, b types.Uint128) (types.Uint128, bool) {
+	aLo, aHi := uint128Words(a)
+	bLo, bHi := uint128Words(b)
+
+	lo, carry := bits.Add64(aLo, bLo, 0)
+	hi, carry := bits.Add64(aHi, bHi, carry)
+	return uint128FromWords(lo, hi), carry != 0
+}
+
+// SubUint128 returns a - b. The boolean result reports whether the subtraction underflowed.
+func SubUint128(a, b types.Uint128) (types.Uint128, bool) {
+	aLo, aHi := uint128Words(a)
+	bLo, bHi := uint128Words(b)
+
+	lo, borrow := bits.Sub64(aLo, bLo, 0)
+	hi, borrow := bits.Sub64(aHi, bHi, borrow)
+	return uint128FromWords(lo, hi), borrow != 0
+}
+
+// CompareUint128 returns -1, 0 or +1 depending on whether a is less than,
+// equal to or greater than b.
+func CompareUint128(a, b types.Uint128) int {
+	aLo, aHi := uint128Words(a)
+	bLo, bHi := uint128Words(b)
+
+	switch {
+	case aHi < bHi:
+		return -1
+	case aHi > bHi:
+		return 1
+	case aLo < bLo:
+		return -1
+	case aLo > bLo:
+		return 1
+	}
+	return 0
+}
+
+// MinUint128 returns the smaller of a and b.
+func MinUint128(a, b types.Uint128) types.Uint128 {
+	if CompareUint128(a, b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// Int128 is a signed integer with a 128-bit magnitude. It is stored as sign
+// and magnitude rather than two's complement, so the difference of any two
+// Uint128 values (e.g. credits minus debits) is representable.
+type Int128 struct {
+	Abs      types.Uint128
+	Negative bool
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of x.
+func (x Int128) Sign() int {
+	switch {
+	case x.Abs == (types.Uint128{}):
+		return 0
+	case x.Negative:
+		return -1
+	}
+	return 1
+}
+
+// Neg returns -x.
+func (x Int128) Neg() Int128 {
+	if x.Sign() == 0 {
+		return Int128{}
+	}
+	return Int128{Abs: x.Abs, Negative: !x.Negative}
+}
+
+// String formats x as a decimal string with a leading '-' when negative.
+func (x Int128) String() string {
+	if x.Sign() < 0 {
+		return "-" + Uint128ToString(x.Abs)
+	}
+	return Uint128ToString(x.Abs)
+}
+
+// DiffUint128 returns a - b as a signed value. It never overflows.
+func DiffUint128(a, b types.Uint128) Int128 {
+	if CompareUint128(a, b) >= 0 {
+		diff, _ := SubUint128(a, b)
+		return Int128{Abs: diff}
+	}
+	diff, _ := SubUint128(b, a)
+	return Int128{Abs: diff, Negative: true}
+}
+
+// AddInt128 returns a + b. The boolean result reports whether the magnitude
+// of the sum overflowed 128 bits.
+func AddInt128(a, b Int128) (Int128, bool) {
+	if a.Sign() == 0 {
+		return b, false
+	}
+	if b.Sign() == 0 || a.Negative == b.Negative {
+		sum, overflow := AddUint128(a.Abs, b.Abs)
+		return Int128{Abs: sum, Negative: a.Negative}, overflow
+	}
+
+	// Opposite signs: the result takes the sign of the larger magnitude
+	diff := DiffUint128(a.Abs, b.Abs)
+	if a.Negative {
+		diff = diff.Neg()
+	}
+	return diff, false
+}
+
+// SubInt128 returns a - b. The boolean result reports whether the magnitude
+// of the difference overflowed 128 bits.
+func SubInt128(a, b Int128) (Int128, bool) {
+	return AddInt128(a, b.Neg())
+}
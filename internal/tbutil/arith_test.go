@@ -0,0 +1,113 @@
+package tbutil_test
+
+import (
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+func mustParse(t *testing.T, s string) types.Uint128 {
+	t.Helper()
+	u, err := tbutil.ParseUint128FromString(s)
+	assert.NoError(t, err)
+	return u
+}
+
+func TestAddUint128(t *testing.T) {
+	t.Run("carries into high word", func(t *testing.T) {
+		sum, overflow := tbutil.AddUint128(mustParse(t, "18446744073709551615"), types.ToUint128(1))
+		assert.False(t, overflow)
+		assert.Equal(t, "18446744073709551616", tbutil.Uint128ToString(sum))
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		_, overflow := tbutil.AddUint128(tbutil.MaxUint128, types.ToUint128(1))
+		assert.True(t, overflow)
+	})
+}
+
+func TestSubUint128(t *testing.T) {
+	t.Run("borrows from high word", func(t *testing.T) {
+		diff, underflow := tbutil.SubUint128(mustParse(t, "18446744073709551616"), types.ToUint128(1))
+		assert.False(t, underflow)
+		assert.Equal(t, "18446744073709551615", tbutil.Uint128ToString(diff))
+	})
+
+	t.Run("underflow", func(t *testing.T) {
+		_, underflow := tbutil.SubUint128(types.ToUint128(1), types.ToUint128(2))
+		assert.True(t, underflow)
+	})
+}
+
+func TestCompareUint128(t *testing.T) {
+	small := types.ToUint128(10)
+	large := mustParse(t, "18446744073709551616")
+
+	assert.Equal(t, -1, tbutil.CompareUint128(small, large))
+	assert.Equal(t, 1, tbutil.CompareUint128(large, small))
+	assert.Equal(t, 0, tbutil.CompareUint128(large, large))
+	assert.Equal(t, small, tbutil.MinUint128(large, small))
+}
+
+func TestDiffUint128(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		diff := tbutil.DiffUint128(types.ToUint128(30), types.ToUint128(10))
+		assert.Equal(t, 1, diff.Sign())
+		assert.Equal(t, "20", diff.String())
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		diff := tbutil.DiffUint128(types.ToUint128(10), types.ToUint128(30))
+		assert.Equal(t, -1, diff.Sign())
+		assert.Equal(t, "-20", diff.String())
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		diff := tbutil.DiffUint128(types.ToUint128(7), types.ToUint128(7))
+		assert.Equal(t, 0, diff.Sign())
+		assert.Equal(t, "0", diff.String())
+	})
+
+	t.Run("full range", func(t *testing.T) {
+		diff := tbutil.DiffUint128(types.ToUint128(0), tbutil.MaxUint128)
+		assert.Equal(t, "-340282366920938463463374607431768211455", diff.String())
+	})
+}
+
+func TestAddInt128(t *testing.T) {
+	neg := func(v uint64) tbutil.Int128 { return tbutil.Int128{Abs: types.ToUint128(v), Negative: true} }
+	pos := func(v uint64) tbutil.Int128 { return tbutil.Int128{Abs: types.ToUint128(v)} }
+
+	tests := []struct {
+		name string
+		a, b tbutil.Int128
+		want string
+	}{
+		{"both positive", pos(2), pos(3), "5"},
+		{"both negative", neg(2), neg(3), "-5"},
+		{"mixed, positive result", pos(5), neg(3), "2"},
+		{"mixed, negative result", pos(3), neg(5), "-2"},
+		{"cancel out", neg(4), pos(4), "0"},
+		{"zero operand", tbutil.Int128{}, neg(1), "-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, overflow := tbutil.AddInt128(tt.a, tt.b)
+			assert.False(t, overflow)
+			assert.Equal(t, tt.want, sum.String())
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		_, overflow := tbutil.AddInt128(tbutil.Int128{Abs: tbutil.MaxUint128, Negative: true}, neg(1))
+		assert.True(t, overflow)
+	})
+
+	t.Run("subtract", func(t *testing.T) {
+		diff, overflow := tbutil.SubInt128(pos(1), pos(3))
+		assert.False(t, overflow)
+		assert.Equal(t, "-2", diff.String())
+	})
+}
@@ -2,6 +2,7 @@ package tbutil
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"math/big"
 
@@ -21,6 +22,11 @@ func Uint128ToUint64Safe(u types.Uint128) (uint64, error) {
 
 // Uint128ToString converts a little-endian Uint128 to a decimal string.
 func Uint128ToString(u types.Uint128) string {
+	return Uint128ToBigInt(u).String()
+}
+
+// Uint128ToBigInt converts a little-endian Uint128 to a big.Int.
+func Uint128ToBigInt(u types.Uint128) *big.Int {
 	reversed := make([]byte, 16)
 	copy(reversed, u[:])
 
@@ -29,7 +35,7 @@ func Uint128ToString(u types.Uint128) string {
 		reversed[i], reversed[15-i] = u[15-i], u[i]
 	}
 
-	return new(big.Int).SetBytes(reversed).String()
+	return new(big.Int).SetBytes(reversed)
 }
 
 // ParseUint128FromString parses a decimal string to a little-endian Uint128.
@@ -39,6 +45,12 @@ func ParseUint128FromString(s string) (types.Uint128, error) {
 	if _, ok := i.SetString(s, 10); !ok {
 		return types.Uint128{}, errors.New("failed to convert string to big.Int")
 	}
+	return BigIntToUint128(i)
+}
+
+// BigIntToUint128 converts a big.Int to a little-endian Uint128.
+// Returns an error if the value is negative or exceeds 128 bits.
+func BigIntToUint128(i *big.Int) (types.Uint128, error) {
 	if i.Sign() < 0 {
 		return types.Uint128{}, errors.New("negative values are not supported for Uint128")
 	}
@@ -58,3 +70,39 @@ func ParseUint128FromString(s string) (types.Uint128, error) {
 
 	return u, nil
 }
+
+// ParseID parses an object ID given either in decimal form or as a UUID
+// (e.g. "0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59"). A UUID is read as a 128-bit
+// big-endian integer, so both forms of the same ID are interchangeable.
+func ParseID(s string) (types.Uint128, error) {
+	if !isUUID(s) {
+		return ParseUint128FromString(s)
+	}
+
+	// Only the 32 digits between the hyphens; a hyphen anywhere else is
+	// rejected by the hex decoder
+	b, err := hex.DecodeString(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36])
+	if err != nil || len(b) != 16 {
+		return types.Uint128{}, errors.New("invalid UUID")
+	}
+
+	var u types.Uint128
+	for i := 0; i < 16; i++ {
+		u[i] = b[15-i]
+	}
+	return u, nil
+}
+
+// FormatUUID formats a Uint128 in the canonical UUID form accepted by ParseID.
+func FormatUUID(u types.Uint128) string {
+	b := make([]byte, 16)
+	for i := 0; i < 16; i++ {
+		b[i] = u[15-i]
+	}
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+func isUUID(s string) bool {
+	return len(s) == 36 && s[8] == '-' && s[13] == '-' && s[18] == '-' && s[23] == '-'
+}
@@ -71,3 +71,77 @@ func TestUint128ToStringAndParse(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to convert")
 	})
 }
+
+func TestBigIntConversion(t *testing.T) {
+	maxUint128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+	u, err := tbutil.BigIntToUint128(maxUint128)
+	assert.NoError(t, err)
+	assert.Equal(t, "340282366920938463463374607431768211455", tbutil.Uint128ToString(u))
+	assert.Equal(t, 0, maxUint128.Cmp(tbutil.Uint128ToBigInt(u)))
+
+	assert.Equal(t, int64(42), tbutil.Uint128ToBigInt(types.ToUint128(42)).Int64())
+
+	_, err = tbutil.BigIntToUint128(big.NewInt(-1))
+	assert.ErrorContains(t, err, "negative")
+	_, err = tbutil.BigIntToUint128(new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.ErrorContains(t, err, "exceeds 128")
+}
+
+func TestParseID(t *testing.T) {
+	t.Run("decimal", func(t *testing.T) {
+		id, err := tbutil.ParseID("42")
+		assert.NoError(t, err)
+		assert.Equal(t, types.ToUint128(42), id)
+	})
+
+	t.Run("uuid round-trip", func(t *testing.T) {
+		uuid := "0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59"
+		id, err := tbutil.ParseID(uuid)
+		assert.NoError(t, err)
+		assert.Equal(t, uuid, tbutil.FormatUUID(id))
+
+		decimal, err := tbutil.ParseID(tbutil.Uint128ToString(id))
+		assert.NoError(t, err)
+		assert.Equal(t, id, decimal)
+	})
+
+	t.Run("uuid is big-endian", func(t *testing.T) {
+		id, err := tbutil.ParseID("00000000-0000-0000-0000-000000000101")
+		assert.NoError(t, err)
+		assert.Equal(t, types.ToUint128(257), id)
+	})
+
+	t.Run("invalid uuid", func(t *testing.T) {
+		for _, s := range []string{
+			"zzzzzzzz-0000-0000-0000-000000000000",
+			"00000000-0000-0000-0000-0000000--000",
+			"00000000-0000-0000-0000-00000000000-",
+			"-0000000-0000-0000-0000-000000000000",
+		} {
+			assert.NotPanics(t, func() {
+				_, err := tbutil.ParseID(s)
+				assert.Error(t, err, s)
+			})
+		}
+	})
+}
+
+func TestUserData(t *testing.T) {
+	t.Run("uuid", func(t *testing.T) {
+		uuid := "0191b7e2-3c6b-7d4e-9a3f-4f1d2c3b4a59"
+		u, err := tbutil.UserDataFromUUID(uuid)
+		assert.NoError(t, err)
+		assert.Equal(t, uuid, tbutil.FormatUUID(u))
+
+		_, err = tbutil.UserDataFromUUID("42")
+		assert.Error(t, err)
+	})
+
+	t.Run("external id", func(t *testing.T) {
+		a := tbutil.UserDataFromExternalID("customer-1")
+		assert.Equal(t, a, tbutil.UserDataFromExternalID("customer-1"))
+		assert.NotEqual(t, a, tbutil.UserDataFromExternalID("customer-2"))
+		assert.NotEqual(t, types.Uint128{}, a)
+	})
+}
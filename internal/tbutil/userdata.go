@@ -0,0 +1,30 @@
+package tbutil
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// UserDataFromUUID encodes a UUID into a UserData128 field, e.g. to link an
+// account to a customer record keyed by UUID. FormatUUID reverses it.
+func UserDataFromUUID(uuid string) (types.Uint128, error) {
+	if !isUUID(uuid) {
+		return types.Uint128{}, errors.New("invalid UUID")
+	}
+	return ParseID(uuid)
+}
+
+// UserDataFromExternalID encodes an arbitrary external identifier, such as a
+// customer ID from another system, into a UserData128 field. The value is the
+// first 16 bytes of the SHA-256 of the identifier: the same identifier always
+// yields the same value, so it can be used to match records, but it cannot be
+// decoded back.
+func UserDataFromExternalID(id string) types.Uint128 {
+	sum := sha256.Sum256([]byte(id))
+
+	var u types.Uint128
+	copy(u[:], sum[:16])
+	return u
+}
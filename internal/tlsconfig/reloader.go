@@ -0,0 +1,145 @@
+// Package tlsconfig builds the server TLS configuration from certificate
+// files and reloads them when they change, so certificates can be rotated
+// without a restart.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/config"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/logger"
+)
+
+// reloadDelay lets a rotation that rewrites several files settle before
+// they are read
+const reloadDelay = 100 * time.Millisecond
+
+// Versions maps the accepted tls.min_version settings to TLS versions.
+var Versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Reloader holds the current server certificate and client CA pool.
+type Reloader struct {
+	cfg       config.TLS
+	cert      atomic.Pointer[tls.Certificate]
+	clientCAs atomic.Pointer[x509.CertPool]
+}
+
+// NewReloader loads the files of cfg.
+func NewReloader(cfg config.TLS) (*Reloader, error) {
+	r := &Reloader{cfg: cfg}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the files again. On error the previous certificates stay in
+// use.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("client CA has no valid certificates")
+		}
+	}
+
+	r.cert.Store(&cert)
+	r.clientCAs.Store(pool)
+	return nil
+}
+
+// ServerConfig returns a configuration that always serves the latest
+// certificates. When a client CA is configured, clients must present a
+// certificate signed by it, unless optionalClientCert is set.
+func (r *Reloader) ServerConfig(optionalClientCert bool) *tls.Config {
+	minVersion := Versions[r.cfg.MinVersion]
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+
+	return &tls.Config{
+		MinVersion: minVersion,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := &tls.Config{
+				Certificates: []tls.Certificate{*r.cert.Load()},
+				MinVersion:   minVersion,
+			}
+			if pool := r.clientCAs.Load(); pool != nil {
+				c.ClientCAs = pool
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+				if optionalClientCert {
+					c.ClientAuth = tls.VerifyClientCertIfGiven
+				}
+			}
+			return c, nil
+		},
+	}
+}
+
+// Watch reloads the certificates whenever a file in their directories
+// changes, until ctx is done. Watching directories rather than files also
+// catches rotations that replace a symlink, as Kubernetes does for secrets.
+func (r *Reloader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	dirs := make(map[string]bool)
+	for _, file := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.ClientCAFile} {
+		if file == "" {
+			continue
+		}
+		dir := filepath.Dir(file)
+		if !dirs[dir] {
+			if err := watcher.Add(dir); err != nil {
+				return fmt.Errorf("failed to watch %s: %w", dir, err)
+			}
+			dirs[dir] = true
+		}
+	}
+
+	timer := time.NewTimer(0)
+	<-timer.C
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-watcher.Events:
+			timer.Reset(reloadDelay)
+		case err := <-watcher.Errors:
+			logger.ErrorContext(ctx, "certificate watch failed", "error", err)
+		case <-timer.C:
+			if err := r.Reload(); err != nil {
+				logger.ErrorContext(ctx, "certificate reload failed, keeping the previous one", "error", err)
+				continue
+			}
+			logger.InfoContext(ctx, "certificates reloaded", "cert_file", r.cfg.CertFile)
+		}
+	}
+}
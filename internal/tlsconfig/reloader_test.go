@@ -0,0 +1,245 @@
+package tlsconfig_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/config"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tlsconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// authority is a self-signed CA generated for a test
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+var serial int64
+
+func newAuthority(t *testing.T) *authority {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial++
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &authority{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM certificate and key for name, valid for localhost
+func (a *authority) issue(t *testing.T, name string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte, serialNumber *big.Int) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, &key.PublicKey, a.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		template.SerialNumber
+}
+
+func (a *authority) clientCert(t *testing.T, name string) tls.Certificate {
+	t.Helper()
+	certPEM, keyPEM, _ := a.issue(t, name, x509.ExtKeyUsageClientAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return cert
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// setup writes a server certificate signed by ca, and the client CA when
+// clientCA is not nil
+func setup(t *testing.T, ca, clientCA *authority) (config.TLS, *big.Int) {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := config.TLS{
+		CertFile:   filepath.Join(dir, "server.crt"),
+		KeyFile:    filepath.Join(dir, "server.key"),
+		MinVersion: "1.2",
+	}
+	certPEM, keyPEM, serialNumber := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	writeFile(t, cfg.CertFile, certPEM)
+	writeFile(t, cfg.KeyFile, keyPEM)
+
+	if clientCA != nil {
+		cfg.ClientCAFile = filepath.Join(dir, "clients.crt")
+		writeFile(t, cfg.ClientCAFile, clientCA.pem)
+	}
+	return cfg, serialNumber
+}
+
+// serve starts a gRPC server answering health checks over TLS
+func serve(t *testing.T, tlsConfig *tls.Config) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	healthpb.RegisterHealthServer(server, grpchealth.NewServer())
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func check(t *testing.T, addr string, clientConfig *tls.Config) error {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(clientConfig)))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func roots(ca *authority) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// servedSerial returns the serial number of the certificate served at addr
+func servedSerial(t *testing.T, addr string, ca *authority) *big.Int {
+	t.Helper()
+	conn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: roots(ca), NextProtos: []string{"h2"}})
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber
+}
+
+func TestServerTLS(t *testing.T) {
+	ca := newAuthority(t)
+	cfg, _ := setup(t, ca, nil)
+	r, err := tlsconfig.NewReloader(cfg)
+	require.NoError(t, err)
+	addr := serve(t, r.ServerConfig(false))
+
+	assert.NoError(t, check(t, addr, &tls.Config{RootCAs: roots(ca)}))
+	assert.Error(t, check(t, addr, &tls.Config{RootCAs: roots(newAuthority(t))}), "untrusted server certificate")
+}
+
+func TestMinVersion(t *testing.T) {
+	ca := newAuthority(t)
+	cfg, _ := setup(t, ca, nil)
+	cfg.MinVersion = "1.3"
+	r, err := tlsconfig.NewReloader(cfg)
+	require.NoError(t, err)
+	addr := serve(t, r.ServerConfig(false))
+
+	assert.NoError(t, check(t, addr, &tls.Config{RootCAs: roots(ca)}))
+	assert.Error(t, check(t, addr, &tls.Config{RootCAs: roots(ca), MaxVersion: tls.VersionTLS12}))
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newAuthority(t)
+	clients := newAuthority(t)
+	cfg, _ := setup(t, ca, clients)
+	r, err := tlsconfig.NewReloader(cfg)
+	require.NoError(t, err)
+
+	t.Run("required", func(t *testing.T) {
+		addr := serve(t, r.ServerConfig(false))
+		assert.NoError(t, check(t, addr, &tls.Config{RootCAs: roots(ca), Certificates: []tls.Certificate{clients.clientCert(t, "payouts")}}))
+		assert.Error(t, check(t, addr, &tls.Config{RootCAs: roots(ca)}), "no client certificate")
+		assert.Error(t, check(t, addr, &tls.Config{RootCAs: roots(ca), Certificates: []tls.Certificate{newAuthority(t).clientCert(t, "payouts")}}), "unknown client CA")
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		addr := serve(t, r.ServerConfig(true))
+		assert.NoError(t, check(t, addr, &tls.Config{RootCAs: roots(ca)}))
+		assert.Error(t, check(t, addr, &tls.Config{RootCAs: roots(ca), Certificates: []tls.Certificate{newAuthority(t).clientCert(t, "payouts")}}), "unknown client CA")
+	})
+}
+
+func TestReloadOnChange(t *testing.T) {
+	ca := newAuthority(t)
+	clients := newAuthority(t)
+	cfg, first := setup(t, ca, clients)
+	r, err := tlsconfig.NewReloader(cfg)
+	require.NoError(t, err)
+	addr := serve(t, r.ServerConfig(true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = r.Watch(ctx) }()
+
+	assert.Equal(t, first, servedSerial(t, addr, ca))
+
+	t.Run("broken files keep the previous certificate", func(t *testing.T) {
+		writeFile(t, cfg.CertFile, []byte("not a certificate"))
+		require.Error(t, r.Reload())
+		assert.Equal(t, first, servedSerial(t, addr, ca))
+	})
+
+	t.Run("rotated certificate", func(t *testing.T) {
+		certPEM, keyPEM, second := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+		writeFile(t, cfg.KeyFile, keyPEM)
+		writeFile(t, cfg.CertFile, certPEM)
+
+		require.Eventually(t, func() bool {
+			return servedSerial(t, addr, ca).Cmp(second) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("rotated client CA", func(t *testing.T) {
+		rotated := newAuthority(t)
+		writeFile(t, cfg.ClientCAFile, rotated.pem)
+
+		cert := rotated.clientCert(t, "payouts")
+		require.Eventually(t, func() bool {
+			return check(t, addr, &tls.Config{RootCAs: roots(ca), Certificates: []tls.Certificate{cert}}) == nil
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
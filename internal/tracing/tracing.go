@@ -0,0 +1,119 @@
+// Package tracing configures OpenTelemetry for the server: the exporter, the
+// W3C trace-context propagator and the gRPC server instrumentation. It also
+// holds the span attributes shared by the service and repository layers.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/stats"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/config"
+)
+
+// ServiceName is reported as service.name on every span.
+const ServiceName = "tigerbeetle-service"
+
+// Attribute keys set on service and repository spans.
+const (
+	LedgerKey    = attribute.Key("tigerbeetle.ledger")
+	CodeKey      = attribute.Key("tigerbeetle.code")
+	ResultKey    = attribute.Key("tigerbeetle.result")
+	BatchSizeKey = attribute.Key("tigerbeetle.batch_size")
+)
+
+// Ledger returns the ledger attribute of a span.
+func Ledger(ledger uint32) attribute.KeyValue {
+	return LedgerKey.Int64(int64(ledger))
+}
+
+// Code returns the account or transfer code attribute of a span.
+func Code(code uint16) attribute.KeyValue {
+	return CodeKey.Int(int(code))
+}
+
+// Result returns the TigerBeetle result name attribute of a span.
+func Result(name string) attribute.KeyValue {
+	return ResultKey.String(name)
+}
+
+// BatchSize returns the number of events of a request.
+func BatchSize(n int) attribute.KeyValue {
+	return BatchSizeKey.Int(n)
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Setup installs the W3C trace-context propagator and, unless the exporter
+// is "none", a tracer provider exporting to it. The stdout exporter writes
+// to w. The returned function flushes pending spans and must be called
+// before exiting.
+func Setup(ctx context.Context, cfg config.Tracing, w io.Writer) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w), stdouttrace.WithPrettyPrint())
+	case "otlp":
+		opts := []otlptracegrpc.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", ServiceName)))
+	if err != nil {
+		return nil, errors.Join(err, exporter.Shutdown(ctx))
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// ServerHandler instruments a gRPC server with a span per RPC, continuing
+// the trace found in the incoming metadata.
+func ServerHandler() stats.Handler {
+	return otelgrpc.NewServerHandler()
+}
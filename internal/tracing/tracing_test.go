@@ -0,0 +1,139 @@
+package tracing_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/config"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/repository"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/service"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tbutil"
+	"github.com/pauloaugusto-dmf/tigerbeetle-service/internal/tracing"
+	pb "github.com/pauloaugusto-dmf/tigerbeetle-service/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb_types "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// The package tracers bind to the first global provider, so every test
+// shares this recorder.
+var recorder = tracetest.NewSpanRecorder()
+
+func TestMain(m *testing.M) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	if _, err := tracing.Setup(context.Background(), config.Tracing{Exporter: "none"}, io.Discard); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func startServer(t *testing.T) (pb.FinancialServiceClient, *repository.TigerBeetleRepository) {
+	t.Helper()
+	repo := repository.NewInMemoryRepository()
+	t.Cleanup(repo.Close)
+
+	server := grpc.NewServer(grpc.StatsHandler(tracing.ServerHandler()))
+	pb.RegisterFinancialServiceServer(server, service.NewFinancialService(repo))
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewFinancialServiceClient(conn), repo
+}
+
+func spanNamed(t *testing.T, traceID, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() == traceID && span.Name() == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "%s in trace %s", name, traceID)
+	return nil
+}
+
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestSpansFollowIncomingTrace(t *testing.T) {
+	client, repo := startServer(t)
+
+	ids := make([]string, 2)
+	for i := range ids {
+		account := tb_types.Account{ID: tb_types.ID(), UserData128: tb_types.ToUint128(1), Ledger: 3, Code: 1}
+		_, err := repo.CreateAccount(context.Background(), account)
+		require.NoError(t, err)
+		ids[i] = tbutil.Uint128ToString(account.ID)
+	}
+
+	call := func(traceID, creditID string) error {
+		ctx := metadata.AppendToOutgoingContext(context.Background(),
+			"traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+		_, err := client.CreateTransfer(ctx, &pb.CreateTransferRequest{
+			DebitAccountId: ids[0], CreditAccountId: creditID, Amount: "10", Ledger: 3, Code: "7",
+		})
+		return err
+	}
+
+	t.Run("successful transfer", func(t *testing.T) {
+		const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		require.NoError(t, call(traceID, ids[1]))
+
+		rpc := spanNamed(t, traceID, "financial.FinancialService/CreateTransfer")
+		assert.Equal(t, "00f067aa0ba902b7", rpc.Parent().SpanID().String())
+		assert.True(t, rpc.Parent().IsRemote())
+
+		svc := spanNamed(t, traceID, "FinancialService.CreateTransfer")
+		assert.Equal(t, rpc.SpanContext().SpanID(), svc.Parent().SpanID())
+		assert.Equal(t, int64(3), attr(svc, tracing.LedgerKey).AsInt64())
+		assert.Equal(t, int64(7), attr(svc, tracing.CodeKey).AsInt64())
+
+		ledger := spanNamed(t, traceID, "TigerBeetleRepository.CreateTransfer")
+		assert.Equal(t, svc.SpanContext().SpanID(), ledger.Parent().SpanID())
+		assert.Equal(t, codes.Unset, ledger.Status().Code)
+	})
+
+	t.Run("rejected transfer", func(t *testing.T) {
+		const traceID = "5bf92f3577b34da6a3ce929d0e0e4736"
+		require.Error(t, call(traceID, "424242"))
+
+		ledger := spanNamed(t, traceID, "TigerBeetleRepository.CreateTransfer")
+		assert.Equal(t, codes.Error, ledger.Status().Code)
+		assert.Equal(t, "credit_account_not_found", attr(ledger, tracing.ResultKey).AsString())
+
+		svc := spanNamed(t, traceID, "FinancialService.CreateTransfer")
+		assert.Equal(t, codes.Error, svc.Status().Code)
+	})
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), config.Tracing{Exporter: "jaeger"}, io.Discard)
+	assert.Error(t, err)
+}
@@ -17,10 +17,6 @@ func ValidateAccount(account tb_types.Account) error {
 		return errors.New("account ID cannot be zero")
 	}
 
-	if IsZeroID(account.UserData128) {
-		return errors.New("user ID cannot be zero")
-	}
-
 	if account.Ledger == 0 {
 		return errors.New("ledger cannot be zero")
 	}
@@ -36,6 +32,17 @@ func ValidateTransfer(transfer tb_types.Transfer) error {
 	if IsZeroID(transfer.ID) {
 		return errors.New("transfer ID cannot be zero")
 	}
+
+	// Posting or voiding inherits accounts, ledger, code and amount from the
+	// pending transfer, so only the pending ID is required.
+	flags := transfer.TransferFlags()
+	if flags.PostPendingTransfer || flags.VoidPendingTransfer {
+		if IsZeroID(transfer.PendingID) {
+			return errors.New("pending ID must be set to post or void a transfer")
+		}
+		return nil
+	}
+
 	if IsZeroID(transfer.DebitAccountID) || IsZeroID(transfer.CreditAccountID) {
 		return errors.New("debit and credit account IDs must be set")
 	}
@@ -2,8 +2,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0-devel
-// 	protoc        v3.14.0
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
 // source: proto/financial.proto
 
 package proto
@@ -13,6 +13,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -22,25 +23,127 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Direção das transferências em relação à conta
+type TransferDirection int32
+
+const (
+	TransferDirection_TRANSFER_DIRECTION_BOTH    TransferDirection = 0
+	TransferDirection_TRANSFER_DIRECTION_DEBITS  TransferDirection = 1
+	TransferDirection_TRANSFER_DIRECTION_CREDITS TransferDirection = 2
+)
+
+// Enum value maps for TransferDirection.
+var (
+	TransferDirection_name = map[int32]string{
+		0: "TRANSFER_DIRECTION_BOTH",
+		1: "TRANSFER_DIRECTION_DEBITS",
+		2: "TRANSFER_DIRECTION_CREDITS",
+	}
+	TransferDirection_value = map[string]int32{
+		"TRANSFER_DIRECTION_BOTH":    0,
+		"TRANSFER_DIRECTION_DEBITS":  1,
+		"TRANSFER_DIRECTION_CREDITS": 2,
+	}
+)
+
+func (x TransferDirection) Enum() *TransferDirection {
+	p := new(TransferDirection)
+	*p = x
+	return p
+}
+
+func (x TransferDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_financial_proto_enumTypes[0].Descriptor()
+}
+
+func (TransferDirection) Type() protoreflect.EnumType {
+	return &file_proto_financial_proto_enumTypes[0]
+}
+
+func (x TransferDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferDirection.Descriptor instead.
+func (TransferDirection) EnumDescriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{0}
+}
+
+type ImportKind int32
+
+const (
+	ImportKind_IMPORT_KIND_UNSPECIFIED ImportKind = 0
+	ImportKind_IMPORT_KIND_ACCOUNTS    ImportKind = 1
+	ImportKind_IMPORT_KIND_TRANSFERS   ImportKind = 2
+)
+
+// Enum value maps for ImportKind.
+var (
+	ImportKind_name = map[int32]string{
+		0: "IMPORT_KIND_UNSPECIFIED",
+		1: "IMPORT_KIND_ACCOUNTS",
+		2: "IMPORT_KIND_TRANSFERS",
+	}
+	ImportKind_value = map[string]int32{
+		"IMPORT_KIND_UNSPECIFIED": 0,
+		"IMPORT_KIND_ACCOUNTS":    1,
+		"IMPORT_KIND_TRANSFERS":   2,
+	}
+)
+
+func (x ImportKind) Enum() *ImportKind {
+	p := new(ImportKind)
+	*p = x
+	return p
+}
+
+func (x ImportKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_financial_proto_enumTypes[1].Descriptor()
+}
+
+func (ImportKind) Type() protoreflect.EnumType {
+	return &file_proto_financial_proto_enumTypes[1]
+}
+
+func (x ImportKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportKind.Descriptor instead.
+func (ImportKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{1}
+}
+
 // Requisição para criar uma conta
 type CreateAccountRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Code   uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Ledger uint32                 `protobuf:"varint,2,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Flags  uint32                 `protobuf:"varint,3,opt,name=flags,proto3" json:"flags,omitempty"`
+	// ID opcional (decimal ou UUID) para criação idempotente
+	Id string `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	// Dados livres do cliente, ex.: o ID do cliente em outro sistema.
+	// user_data_128 aceita decimal ou UUID
+	UserData_128  string `protobuf:"bytes,6,opt,name=user_data_128,json=userData128,proto3" json:"user_data_128,omitempty"`
+	UserData_64   uint64 `protobuf:"varint,7,opt,name=user_data_64,json=userData64,proto3" json:"user_data_64,omitempty"`
+	UserData_32   uint32 `protobuf:"varint,8,opt,name=user_data_32,json=userData32,proto3" json:"user_data_32,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Code     uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Ledger   uint32 `protobuf:"varint,2,opt,name=ledger,proto3" json:"ledger,omitempty"`
-	Flags    uint32 `protobuf:"varint,3,opt,name=flags,proto3" json:"flags,omitempty"`
-	UserData string `protobuf:"bytes,4,opt,name=user_data,json=userData,proto3" json:"user_data,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateAccountRequest) Reset() {
 	*x = CreateAccountRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_financial_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_proto_financial_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CreateAccountRequest) String() string {
@@ -51,7 +154,7 @@ func (*CreateAccountRequest) ProtoMessage() {}
 
 func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_financial_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -87,29 +190,47 @@ func (x *CreateAccountRequest) GetFlags() uint32 {
 	return 0
 }
 
-func (x *CreateAccountRequest) GetUserData() string {
+func (x *CreateAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetUserData_128() string {
 	if x != nil {
-		return x.UserData
+		return x.UserData_128
 	}
 	return ""
 }
 
+func (x *CreateAccountRequest) GetUserData_64() uint64 {
+	if x != nil {
+		return x.UserData_64
+	}
+	return 0
+}
+
+func (x *CreateAccountRequest) GetUserData_32() uint32 {
+	if x != nil {
+		return x.UserData_32
+	}
+	return 0
+}
+
 // Requisição para buscar uma conta
 type GetAccountRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetAccountRequest) Reset() {
 	*x = GetAccountRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_financial_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_proto_financial_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetAccountRequest) String() string {
@@ -120,7 +241,7 @@ func (*GetAccountRequest) ProtoMessage() {}
 
 func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_financial_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -144,27 +265,33 @@ func (x *GetAccountRequest) GetId() string {
 
 // Resposta de uma operação com conta
 type AccountResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code         uint32                 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Ledger       uint32                 `protobuf:"varint,3,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Flags        uint32                 `protobuf:"varint,5,opt,name=flags,proto3" json:"flags,omitempty"`
+	Success      bool                   `protobuf:"varint,7,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// Saldo líquido (credits_posted - debits_posted), decimal com sinal
+	Balance string `protobuf:"bytes,9,opt,name=balance,proto3" json:"balance,omitempty"`
+	// Totais da conta em decimal (até 128 bits)
+	DebitsPending  string `protobuf:"bytes,10,opt,name=debits_pending,json=debitsPending,proto3" json:"debits_pending,omitempty"`
+	DebitsPosted   string `protobuf:"bytes,11,opt,name=debits_posted,json=debitsPosted,proto3" json:"debits_posted,omitempty"`
+	CreditsPending string `protobuf:"bytes,12,opt,name=credits_pending,json=creditsPending,proto3" json:"credits_pending,omitempty"`
+	CreditsPosted  string `protobuf:"bytes,13,opt,name=credits_posted,json=creditsPosted,proto3" json:"credits_posted,omitempty"`
+	// user_data_128 em decimal
+	UserData_128  string `protobuf:"bytes,14,opt,name=user_data_128,json=userData128,proto3" json:"user_data_128,omitempty"`
+	UserData_64   uint64 `protobuf:"varint,15,opt,name=user_data_64,json=userData64,proto3" json:"user_data_64,omitempty"`
+	UserData_32   uint32 `protobuf:"varint,16,opt,name=user_data_32,json=userData32,proto3" json:"user_data_32,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Id           string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Code         uint32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
-	Ledger       uint32 `protobuf:"varint,3,opt,name=ledger,proto3" json:"ledger,omitempty"`
-	Balance      int64  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
-	Flags        uint32 `protobuf:"varint,5,opt,name=flags,proto3" json:"flags,omitempty"`
-	UserData     string `protobuf:"bytes,6,opt,name=user_data,json=userData,proto3" json:"user_data,omitempty"`
-	Success      bool   `protobuf:"varint,7,opt,name=success,proto3" json:"success,omitempty"`
-	ErrorMessage string `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AccountResponse) Reset() {
 	*x = AccountResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_financial_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_proto_financial_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *AccountResponse) String() string {
@@ -175,7 +302,7 @@ func (*AccountResponse) ProtoMessage() {}
 
 func (x *AccountResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_financial_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -211,62 +338,110 @@ func (x *AccountResponse) GetLedger() uint32 {
 	return 0
 }
 
-func (x *AccountResponse) GetBalance() int64 {
+func (x *AccountResponse) GetFlags() uint32 {
 	if x != nil {
-		return x.Balance
+		return x.Flags
 	}
 	return 0
 }
 
-func (x *AccountResponse) GetFlags() uint32 {
+func (x *AccountResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Flags
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *AccountResponse) GetUserData() string {
+func (x *AccountResponse) GetErrorMessage() string {
 	if x != nil {
-		return x.UserData
+		return x.ErrorMessage
 	}
 	return ""
 }
 
-func (x *AccountResponse) GetSuccess() bool {
+func (x *AccountResponse) GetBalance() string {
 	if x != nil {
-		return x.Success
+		return x.Balance
 	}
-	return false
+	return ""
 }
 
-func (x *AccountResponse) GetErrorMessage() string {
+func (x *AccountResponse) GetDebitsPending() string {
 	if x != nil {
-		return x.ErrorMessage
+		return x.DebitsPending
+	}
+	return ""
+}
+
+func (x *AccountResponse) GetDebitsPosted() string {
+	if x != nil {
+		return x.DebitsPosted
+	}
+	return ""
+}
+
+func (x *AccountResponse) GetCreditsPending() string {
+	if x != nil {
+		return x.CreditsPending
+	}
+	return ""
+}
+
+func (x *AccountResponse) GetCreditsPosted() string {
+	if x != nil {
+		return x.CreditsPosted
+	}
+	return ""
+}
+
+func (x *AccountResponse) GetUserData_128() string {
+	if x != nil {
+		return x.UserData_128
 	}
 	return ""
 }
 
+func (x *AccountResponse) GetUserData_64() uint64 {
+	if x != nil {
+		return x.UserData_64
+	}
+	return 0
+}
+
+func (x *AccountResponse) GetUserData_32() uint32 {
+	if x != nil {
+		return x.UserData_32
+	}
+	return 0
+}
+
 // Requisição para criar uma transferência
 type CreateTransferRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DebitAccountId  string                 `protobuf:"bytes,1,opt,name=debit_account_id,json=debitAccountId,proto3" json:"debit_account_id,omitempty"`
+	CreditAccountId string                 `protobuf:"bytes,2,opt,name=credit_account_id,json=creditAccountId,proto3" json:"credit_account_id,omitempty"`
+	Ledger          uint32                 `protobuf:"varint,4,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Code            string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	Flags           uint32                 `protobuf:"varint,6,opt,name=flags,proto3" json:"flags,omitempty"`
+	PendingId       string                 `protobuf:"bytes,7,opt,name=pending_id,json=pendingId,proto3" json:"pending_id,omitempty"`
+	Timeout         uint32                 `protobuf:"varint,8,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// ID opcional (decimal ou UUID) para criação idempotente
+	Id string `protobuf:"bytes,9,opt,name=id,proto3" json:"id,omitempty"`
+	// Valor em decimal (até 128 bits)
+	Amount string `protobuf:"bytes,10,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Dados livres do cliente; user_data_128 aceita decimal ou UUID
+	UserData_128  string `protobuf:"bytes,11,opt,name=user_data_128,json=userData128,proto3" json:"user_data_128,omitempty"`
+	UserData_64   uint64 `protobuf:"varint,12,opt,name=user_data_64,json=userData64,proto3" json:"user_data_64,omitempty"`
+	UserData_32   uint32 `protobuf:"varint,13,opt,name=user_data_32,json=userData32,proto3" json:"user_data_32,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	DebitAccountId  string `protobuf:"bytes,1,opt,name=debit_account_id,json=debitAccountId,proto3" json:"debit_account_id,omitempty"`
-	CreditAccountId string `protobuf:"bytes,2,opt,name=credit_account_id,json=creditAccountId,proto3" json:"credit_account_id,omitempty"`
-	Amount          uint64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Ledger          uint32 `protobuf:"varint,4,opt,name=ledger,proto3" json:"ledger,omitempty"`
-	Code            string `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
-	Flags           uint32 `protobuf:"varint,6,opt,name=flags,proto3" json:"flags,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateTransferRequest) Reset() {
 	*x = CreateTransferRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_financial_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_proto_financial_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *CreateTransferRequest) String() string {
@@ -277,7 +452,7 @@ func (*CreateTransferRequest) ProtoMessage() {}
 
 func (x *CreateTransferRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_financial_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -306,13 +481,6 @@ func (x *CreateTransferRequest) GetCreditAccountId() string {
 	return ""
 }
 
-func (x *CreateTransferRequest) GetAmount() uint64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
-}
-
 func (x *CreateTransferRequest) GetLedger() uint32 {
 	if x != nil {
 		return x.Ledger
@@ -334,22 +502,68 @@ func (x *CreateTransferRequest) GetFlags() uint32 {
 	return 0
 }
 
+func (x *CreateTransferRequest) GetPendingId() string {
+	if x != nil {
+		return x.PendingId
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetTimeout() uint32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *CreateTransferRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetUserData_128() string {
+	if x != nil {
+		return x.UserData_128
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetUserData_64() uint64 {
+	if x != nil {
+		return x.UserData_64
+	}
+	return 0
+}
+
+func (x *CreateTransferRequest) GetUserData_32() uint32 {
+	if x != nil {
+		return x.UserData_32
+	}
+	return 0
+}
+
 // Requisição para buscar uma transferência
 type GetTransferRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTransferRequest) Reset() {
 	*x = GetTransferRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_financial_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_proto_financial_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *GetTransferRequest) String() string {
@@ -360,7 +574,7 @@ func (*GetTransferRequest) ProtoMessage() {}
 
 func (x *GetTransferRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_financial_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -384,29 +598,34 @@ func (x *GetTransferRequest) GetId() string {
 
 // Resposta de uma operação com transferência
 type TransferResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DebitAccountId  string                 `protobuf:"bytes,2,opt,name=debit_account_id,json=debitAccountId,proto3" json:"debit_account_id,omitempty"`
+	CreditAccountId string                 `protobuf:"bytes,3,opt,name=credit_account_id,json=creditAccountId,proto3" json:"credit_account_id,omitempty"`
+	Ledger          uint32                 `protobuf:"varint,5,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Code            string                 `protobuf:"bytes,6,opt,name=code,proto3" json:"code,omitempty"`
+	Flags           uint32                 `protobuf:"varint,7,opt,name=flags,proto3" json:"flags,omitempty"`
+	// Timestamp do cluster em nanossegundos
+	Timestamp    uint64 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Success      bool   `protobuf:"varint,9,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string `protobuf:"bytes,10,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	PendingId    string `protobuf:"bytes,11,opt,name=pending_id,json=pendingId,proto3" json:"pending_id,omitempty"`
+	Timeout      uint32 `protobuf:"varint,12,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Valor em decimal (até 128 bits)
+	Amount string `protobuf:"bytes,13,opt,name=amount,proto3" json:"amount,omitempty"`
+	// user_data_128 em decimal
+	UserData_128  string `protobuf:"bytes,14,opt,name=user_data_128,json=userData128,proto3" json:"user_data_128,omitempty"`
+	UserData_64   uint64 `protobuf:"varint,15,opt,name=user_data_64,json=userData64,proto3" json:"user_data_64,omitempty"`
+	UserData_32   uint32 `protobuf:"varint,16,opt,name=user_data_32,json=userData32,proto3" json:"user_data_32,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Id              string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	DebitAccountId  string `protobuf:"bytes,2,opt,name=debit_account_id,json=debitAccountId,proto3" json:"debit_account_id,omitempty"`
-	CreditAccountId string `protobuf:"bytes,3,opt,name=credit_account_id,json=creditAccountId,proto3" json:"credit_account_id,omitempty"`
-	Amount          uint64 `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Ledger          uint32 `protobuf:"varint,5,opt,name=ledger,proto3" json:"ledger,omitempty"`
-	Code            string `protobuf:"bytes,6,opt,name=code,proto3" json:"code,omitempty"`
-	Flags           uint32 `protobuf:"varint,7,opt,name=flags,proto3" json:"flags,omitempty"`
-	Timestamp       uint32 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Success         bool   `protobuf:"varint,9,opt,name=success,proto3" json:"success,omitempty"`
-	ErrorMessage    string `protobuf:"bytes,10,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransferResponse) Reset() {
 	*x = TransferResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_financial_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_proto_financial_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransferResponse) String() string {
@@ -417,7 +636,7 @@ func (*TransferResponse) ProtoMessage() {}
 
 func (x *TransferResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_financial_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -453,13 +672,6 @@ func (x *TransferResponse) GetCreditAccountId() string {
 	return ""
 }
 
-func (x *TransferResponse) GetAmount() uint64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
-}
-
 func (x *TransferResponse) GetLedger() uint32 {
 	if x != nil {
 		return x.Ledger
@@ -481,7 +693,7 @@ func (x *TransferResponse) GetFlags() uint32 {
 	return 0
 }
 
-func (x *TransferResponse) GetTimestamp() uint32 {
+func (x *TransferResponse) GetTimestamp() uint64 {
 	if x != nil {
 		return x.Timestamp
 	}
@@ -502,226 +714,2440 @@ func (x *TransferResponse) GetErrorMessage() string {
 	return ""
 }
 
-var File_proto_financial_proto protoreflect.FileDescriptor
-
-var file_proto_financial_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61,
-	0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69,
-	0x61, 0x6c, 0x22, 0x75, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
-	0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1b, 0x0a, 0x09,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x22, 0x23, 0x0a, 0x11, 0x47, 0x65, 0x74,
-	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xd9,
-	0x01, 0x0a, 0x0f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x18,
-	0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67,
-	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1b,
-	0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x73,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xc7, 0x01, 0x0a, 0x15, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x65, 0x62, 0x69, 0x74, 0x5f, 0x61, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
-	0x64, 0x65, 0x62, 0x69, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2a,
-	0x0a, 0x11, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x64, 0x69,
-	0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x06, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66,
-	0x6c, 0x61, 0x67, 0x73, 0x22, 0x24, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xaf, 0x02, 0x0a, 0x10, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x28, 0x0a, 0x10, 0x64, 0x65, 0x62, 0x69, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x62, 0x69, 0x74,
-	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x72, 0x65,
-	0x64, 0x69, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x41, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c,
-	0x65, 0x64, 0x67, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61,
-	0x67, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12,
-	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xc4, 0x02, 0x0a,
-	0x10, 0x46, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x4c, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2e,
-	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x46, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x2e,
-	0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x66, 0x69,
-	0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x66, 0x69, 0x6e, 0x61,
-	0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x69,
-	0x6e, 0x61, 0x6e, 0x63, 0x69, 0x61, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x1d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63,
-	0x69, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x69,
-	0x61, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x1b, 0x5a, 0x19, 0x74, 0x69, 0x67, 0x65, 0x72, 0x62, 0x65, 0x65, 0x74,
-	0x6c, 0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *TransferResponse) GetPendingId() string {
+	if x != nil {
+		return x.PendingId
+	}
+	return ""
 }
 
-var (
-	file_proto_financial_proto_rawDescOnce sync.Once
-	file_proto_financial_proto_rawDescData = file_proto_financial_proto_rawDesc
-)
+func (x *TransferResponse) GetTimeout() uint32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
 
-func file_proto_financial_proto_rawDescGZIP() []byte {
-	file_proto_financial_proto_rawDescOnce.Do(func() {
-		file_proto_financial_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_financial_proto_rawDescData)
-	})
-	return file_proto_financial_proto_rawDescData
+func (x *TransferResponse) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
 }
 
-var file_proto_financial_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_proto_financial_proto_goTypes = []interface{}{
-	(*CreateAccountRequest)(nil),  // 0: financial.CreateAccountRequest
-	(*GetAccountRequest)(nil),     // 1: financial.GetAccountRequest
-	(*AccountResponse)(nil),       // 2: financial.AccountResponse
-	(*CreateTransferRequest)(nil), // 3: financial.CreateTransferRequest
-	(*GetTransferRequest)(nil),    // 4: financial.GetTransferRequest
-	(*TransferResponse)(nil),      // 5: financial.TransferResponse
+func (x *TransferResponse) GetUserData_128() string {
+	if x != nil {
+		return x.UserData_128
+	}
+	return ""
 }
-var file_proto_financial_proto_depIdxs = []int32{
-	0, // 0: financial.FinancialService.CreateAccount:input_type -> financial.CreateAccountRequest
-	1, // 1: financial.FinancialService.GetAccount:input_type -> financial.GetAccountRequest
-	3, // 2: financial.FinancialService.CreateTransfer:input_type -> financial.CreateTransferRequest
-	4, // 3: financial.FinancialService.GetTransfer:input_type -> financial.GetTransferRequest
-	2, // 4: financial.FinancialService.CreateAccount:output_type -> financial.AccountResponse
-	2, // 5: financial.FinancialService.GetAccount:output_type -> financial.AccountResponse
-	5, // 6: financial.FinancialService.CreateTransfer:output_type -> financial.TransferResponse
-	5, // 7: financial.FinancialService.GetTransfer:output_type -> financial.TransferResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+func (x *TransferResponse) GetUserData_64() uint64 {
+	if x != nil {
+		return x.UserData_64
+	}
+	return 0
 }
 
-func init() { file_proto_financial_proto_init() }
-func file_proto_financial_proto_init() {
-	if File_proto_financial_proto != nil {
-		return
+func (x *TransferResponse) GetUserData_32() uint32 {
+	if x != nil {
+		return x.UserData_32
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_financial_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAccountRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_financial_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAccountRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_financial_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AccountResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_financial_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateTransferRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_financial_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTransferRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_proto_financial_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return 0
+}
+
+// Requisição para reservar fundos (transferência pendente)
+type ReserveFundsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DebitAccountId  string                 `protobuf:"bytes,1,opt,name=debit_account_id,json=debitAccountId,proto3" json:"debit_account_id,omitempty"`
+	CreditAccountId string                 `protobuf:"bytes,2,opt,name=credit_account_id,json=creditAccountId,proto3" json:"credit_account_id,omitempty"`
+	Ledger          uint32                 `protobuf:"varint,4,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Code            string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	// Tempo em segundos até a reserva expirar (0 = sem expiração)
+	Timeout uint32 `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// ID opcional (decimal ou UUID) para criação idempotente
+	Id string `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	// Valor em decimal (até 128 bits)
+	Amount string `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Dados livres do cliente; user_data_128 aceita decimal ou UUID
+	UserData_128  string `protobuf:"bytes,9,opt,name=user_data_128,json=userData128,proto3" json:"user_data_128,omitempty"`
+	UserData_64   uint64 `protobuf:"varint,10,opt,name=user_data_64,json=userData64,proto3" json:"user_data_64,omitempty"`
+	UserData_32   uint32 `protobuf:"varint,11,opt,name=user_data_32,json=userData32,proto3" json:"user_data_32,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveFundsRequest) Reset() {
+	*x = ReserveFundsRequest{}
+	mi := &file_proto_financial_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveFundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveFundsRequest) ProtoMessage() {}
+
+func (x *ReserveFundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveFundsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveFundsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReserveFundsRequest) GetDebitAccountId() string {
+	if x != nil {
+		return x.DebitAccountId
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetCreditAccountId() string {
+	if x != nil {
+		return x.CreditAccountId
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetLedger() uint32 {
+	if x != nil {
+		return x.Ledger
+	}
+	return 0
+}
+
+func (x *ReserveFundsRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetTimeout() uint32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *ReserveFundsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetUserData_128() string {
+	if x != nil {
+		return x.UserData_128
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetUserData_64() uint64 {
+	if x != nil {
+		return x.UserData_64
+	}
+	return 0
+}
+
+func (x *ReserveFundsRequest) GetUserData_32() uint32 {
+	if x != nil {
+		return x.UserData_32
+	}
+	return 0
+}
+
+// Requisição para capturar uma transferência pendente
+type CapturePendingRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PendingId string                 `protobuf:"bytes,1,opt,name=pending_id,json=pendingId,proto3" json:"pending_id,omitempty"`
+	// ID opcional (decimal ou UUID) da transferência de captura
+	Id string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// Valor a capturar em decimal; vazio ou "0" captura o valor total reservado
+	Amount        string `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturePendingRequest) Reset() {
+	*x = CapturePendingRequest{}
+	mi := &file_proto_financial_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePendingRequest) ProtoMessage() {}
+
+func (x *CapturePendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePendingRequest.ProtoReflect.Descriptor instead.
+func (*CapturePendingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CapturePendingRequest) GetPendingId() string {
+	if x != nil {
+		return x.PendingId
+	}
+	return ""
+}
+
+func (x *CapturePendingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CapturePendingRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+// Requisição para cancelar uma transferência pendente
+type VoidPendingRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PendingId string                 `protobuf:"bytes,1,opt,name=pending_id,json=pendingId,proto3" json:"pending_id,omitempty"`
+	// ID opcional (decimal ou UUID) da transferência de cancelamento
+	Id            string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoidPendingRequest) Reset() {
+	*x = VoidPendingRequest{}
+	mi := &file_proto_financial_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoidPendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoidPendingRequest) ProtoMessage() {}
+
+func (x *VoidPendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoidPendingRequest.ProtoReflect.Descriptor instead.
+func (*VoidPendingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VoidPendingRequest) GetPendingId() string {
+	if x != nil {
+		return x.PendingId
+	}
+	return ""
+}
+
+func (x *VoidPendingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Perna de uma transferência encadeada
+type TransferLeg struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DebitAccountId  string                 `protobuf:"bytes,1,opt,name=debit_account_id,json=debitAccountId,proto3" json:"debit_account_id,omitempty"`
+	CreditAccountId string                 `protobuf:"bytes,2,opt,name=credit_account_id,json=creditAccountId,proto3" json:"credit_account_id,omitempty"`
+	Ledger          uint32                 `protobuf:"varint,4,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Code            string                 `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	// Flags adicionais da perna; a flag linked é definida pelo servidor
+	Flags uint32 `protobuf:"varint,6,opt,name=flags,proto3" json:"flags,omitempty"`
+	// ID opcional (decimal ou UUID) para criação idempotente
+	Id string `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	// Valor em decimal (até 128 bits)
+	Amount string `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Dados livres do cliente; user_data_128 aceita decimal ou UUID
+	UserData_128  string `protobuf:"bytes,9,opt,name=user_data_128,json=userData128,proto3" json:"user_data_128,omitempty"`
+	UserData_64   uint64 `protobuf:"varint,10,opt,name=user_data_64,json=userData64,proto3" json:"user_data_64,omitempty"`
+	UserData_32   uint32 `protobuf:"varint,11,opt,name=user_data_32,json=userData32,proto3" json:"user_data_32,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferLeg) Reset() {
+	*x = TransferLeg{}
+	mi := &file_proto_financial_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLeg) ProtoMessage() {}
+
+func (x *TransferLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLeg.ProtoReflect.Descriptor instead.
+func (*TransferLeg) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TransferLeg) GetDebitAccountId() string {
+	if x != nil {
+		return x.DebitAccountId
+	}
+	return ""
+}
+
+func (x *TransferLeg) GetCreditAccountId() string {
+	if x != nil {
+		return x.CreditAccountId
+	}
+	return ""
+}
+
+func (x *TransferLeg) GetLedger() uint32 {
+	if x != nil {
+		return x.Ledger
+	}
+	return 0
+}
+
+func (x *TransferLeg) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TransferLeg) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *TransferLeg) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransferLeg) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *TransferLeg) GetUserData_128() string {
+	if x != nil {
+		return x.UserData_128
+	}
+	return ""
+}
+
+func (x *TransferLeg) GetUserData_64() uint64 {
+	if x != nil {
+		return x.UserData_64
+	}
+	return 0
+}
+
+func (x *TransferLeg) GetUserData_32() uint32 {
+	if x != nil {
+		return x.UserData_32
+	}
+	return 0
+}
+
+// Requisição para criar transferências encadeadas, aplicadas todas ou nenhuma
+type CreateLinkedTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Legs          []*TransferLeg         `protobuf:"bytes,1,rep,name=legs,proto3" json:"legs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLinkedTransfersRequest) Reset() {
+	*x = CreateLinkedTransfersRequest{}
+	mi := &file_proto_financial_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLinkedTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLinkedTransfersRequest) ProtoMessage() {}
+
+func (x *CreateLinkedTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLinkedTransfersRequest.ProtoReflect.Descriptor instead.
+func (*CreateLinkedTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateLinkedTransfersRequest) GetLegs() []*TransferLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+// Resultado de uma perna da cadeia
+type TransferLegResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id    string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Código de resultado do TigerBeetle (0 = ok)
+	ResultCode uint32 `protobuf:"varint,3,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	// Nome do resultado, ex.: "exceeds_credits"
+	Result        string `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferLegResult) Reset() {
+	*x = TransferLegResult{}
+	mi := &file_proto_financial_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLegResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLegResult) ProtoMessage() {}
+
+func (x *TransferLegResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLegResult.ProtoReflect.Descriptor instead.
+func (*TransferLegResult) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TransferLegResult) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TransferLegResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TransferLegResult) GetResultCode() uint32 {
+	if x != nil {
+		return x.ResultCode
+	}
+	return 0
+}
+
+func (x *TransferLegResult) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+// Resposta de uma cadeia de transferências
+type LinkedTransfersResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*TransferLegResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Success bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// Índice da perna que quebrou a cadeia (-1 quando todas foram aplicadas)
+	FailedIndex   int32  `protobuf:"varint,3,opt,name=failed_index,json=failedIndex,proto3" json:"failed_index,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkedTransfersResponse) Reset() {
+	*x = LinkedTransfersResponse{}
+	mi := &file_proto_financial_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkedTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkedTransfersResponse) ProtoMessage() {}
+
+func (x *LinkedTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkedTransfersResponse.ProtoReflect.Descriptor instead.
+func (*LinkedTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LinkedTransfersResponse) GetResults() []*TransferLegResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *LinkedTransfersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LinkedTransfersResponse) GetFailedIndex() int32 {
+	if x != nil {
+		return x.FailedIndex
+	}
+	return 0
+}
+
+func (x *LinkedTransfersResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// Requisição para criar várias contas de uma vez
+type CreateAccountsBatchRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Accounts      []*CreateAccountRequest `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountsBatchRequest) Reset() {
+	*x = CreateAccountsBatchRequest{}
+	mi := &file_proto_financial_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountsBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountsBatchRequest) ProtoMessage() {}
+
+func (x *CreateAccountsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountsBatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateAccountsBatchRequest) GetAccounts() []*CreateAccountRequest {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+// Requisição para criar várias transferências de uma vez
+type CreateTransfersBatchRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Transfers     []*CreateTransferRequest `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransfersBatchRequest) Reset() {
+	*x = CreateTransfersBatchRequest{}
+	mi := &file_proto_financial_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransfersBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransfersBatchRequest) ProtoMessage() {}
+
+func (x *CreateTransfersBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransfersBatchRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransfersBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateTransfersBatchRequest) GetTransfers() []*CreateTransferRequest {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+// Resultado de um item do lote
+type BatchItemResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Posição do item na requisição
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id    string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Código de resultado do TigerBeetle (0 = ok)
+	ResultCode uint32 `protobuf:"varint,3,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	// Nome do resultado, ex.: "exists"; "not_attempted" quando o item não
+	// chegou ao TigerBeetle
+	Result string `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	// O item não foi enviado porque um pedido anterior do lote falhou; pode
+	// ser reenviado com o mesmo ID
+	NotAttempted  bool `protobuf:"varint,5,opt,name=not_attempted,json=notAttempted,proto3" json:"not_attempted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	mi := &file_proto_financial_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemResult) ProtoMessage() {}
+
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchItemResult) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetResultCode() uint32 {
+	if x != nil {
+		return x.ResultCode
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetNotAttempted() bool {
+	if x != nil {
+		return x.NotAttempted
+	}
+	return false
+}
+
+// Resposta de uma operação em lote, com um resultado por item
+type BatchResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*BatchItemResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created uint32                 `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Failed  uint32                 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Itens que já existiam com os mesmos campos (reenvio idêntico)
+	AlreadyExisted uint32 `protobuf:"varint,4,opt,name=already_existed,json=alreadyExisted,proto3" json:"already_existed,omitempty"`
+	NotAttempted   uint32 `protobuf:"varint,5,opt,name=not_attempted,json=notAttempted,proto3" json:"not_attempted,omitempty"`
+	// Motivo de os itens restantes não terem sido enviados
+	ErrorMessage  string `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchResponse) Reset() {
+	*x = BatchResponse{}
+	mi := &file_proto_financial_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResponse) ProtoMessage() {}
+
+func (x *BatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResponse.ProtoReflect.Descriptor instead.
+func (*BatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchResponse) GetResults() []*BatchItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchResponse) GetCreated() uint32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *BatchResponse) GetFailed() uint32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BatchResponse) GetAlreadyExisted() uint32 {
+	if x != nil {
+		return x.AlreadyExisted
+	}
+	return 0
+}
+
+func (x *BatchResponse) GetNotAttempted() uint32 {
+	if x != nil {
+		return x.NotAttempted
+	}
+	return 0
+}
+
+func (x *BatchResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// Requisição para listar o histórico de transferências de uma conta
+type ListAccountTransfersRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Direction TransferDirection      `protobuf:"varint,2,opt,name=direction,proto3,enum=financial.TransferDirection" json:"direction,omitempty"`
+	// Intervalo de timestamps (inclusivo, 0 = sem limite)
+	TimestampMin uint64 `protobuf:"varint,3,opt,name=timestamp_min,json=timestampMin,proto3" json:"timestamp_min,omitempty"`
+	TimestampMax uint64 `protobuf:"varint,4,opt,name=timestamp_max,json=timestampMax,proto3" json:"timestamp_max,omitempty"`
+	// Tamanho da página (padrão 50)
+	Limit uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Ordena do mais recente para o mais antigo
+	Reversed bool `protobuf:"varint,6,opt,name=reversed,proto3" json:"reversed,omitempty"`
+	// Cursor opaco retornado pela página anterior
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountTransfersRequest) Reset() {
+	*x = ListAccountTransfersRequest{}
+	mi := &file_proto_financial_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountTransfersRequest) ProtoMessage() {}
+
+func (x *ListAccountTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListAccountTransfersRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListAccountTransfersRequest) GetDirection() TransferDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return TransferDirection_TRANSFER_DIRECTION_BOTH
+}
+
+func (x *ListAccountTransfersRequest) GetTimestampMin() uint64 {
+	if x != nil {
+		return x.TimestampMin
+	}
+	return 0
+}
+
+func (x *ListAccountTransfersRequest) GetTimestampMax() uint64 {
+	if x != nil {
+		return x.TimestampMax
+	}
+	return 0
+}
+
+func (x *ListAccountTransfersRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAccountTransfersRequest) GetReversed() bool {
+	if x != nil {
+		return x.Reversed
+	}
+	return false
+}
+
+func (x *ListAccountTransfersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Página do histórico de transferências
+type ListAccountTransfersResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Transfers []*TransferResponse    `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	// Cursor da próxima página (vazio quando não há mais resultados)
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountTransfersResponse) Reset() {
+	*x = ListAccountTransfersResponse{}
+	mi := &file_proto_financial_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountTransfersResponse) ProtoMessage() {}
+
+func (x *ListAccountTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListAccountTransfersResponse) GetTransfers() []*TransferResponse {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *ListAccountTransfersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Saldo de uma conta em um instante
+type AccountBalance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Timestamp da transferência que produziu o saldo (0 = nenhuma)
+	Timestamp uint64 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Totais em decimal (até 128 bits)
+	DebitsPending  string `protobuf:"bytes,6,opt,name=debits_pending,json=debitsPending,proto3" json:"debits_pending,omitempty"`
+	DebitsPosted   string `protobuf:"bytes,7,opt,name=debits_posted,json=debitsPosted,proto3" json:"debits_posted,omitempty"`
+	CreditsPending string `protobuf:"bytes,8,opt,name=credits_pending,json=creditsPending,proto3" json:"credits_pending,omitempty"`
+	CreditsPosted  string `protobuf:"bytes,9,opt,name=credits_posted,json=creditsPosted,proto3" json:"credits_posted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AccountBalance) Reset() {
+	*x = AccountBalance{}
+	mi := &file_proto_financial_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountBalance) ProtoMessage() {}
+
+func (x *AccountBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
+func (*AccountBalance) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AccountBalance) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *AccountBalance) GetDebitsPending() string {
+	if x != nil {
+		return x.DebitsPending
+	}
+	return ""
+}
+
+func (x *AccountBalance) GetDebitsPosted() string {
+	if x != nil {
+		return x.DebitsPosted
+	}
+	return ""
+}
+
+func (x *AccountBalance) GetCreditsPending() string {
+	if x != nil {
+		return x.CreditsPending
+	}
+	return ""
+}
+
+func (x *AccountBalance) GetCreditsPosted() string {
+	if x != nil {
+		return x.CreditsPosted
+	}
+	return ""
+}
+
+// Requisição para listar os saldos históricos de uma conta
+type GetAccountBalancesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Direction TransferDirection      `protobuf:"varint,2,opt,name=direction,proto3,enum=financial.TransferDirection" json:"direction,omitempty"`
+	// Intervalo de timestamps (inclusivo, 0 = sem limite)
+	TimestampMin uint64 `protobuf:"varint,3,opt,name=timestamp_min,json=timestampMin,proto3" json:"timestamp_min,omitempty"`
+	TimestampMax uint64 `protobuf:"varint,4,opt,name=timestamp_max,json=timestampMax,proto3" json:"timestamp_max,omitempty"`
+	// Tamanho da página (padrão 50)
+	Limit    uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Reversed bool   `protobuf:"varint,6,opt,name=reversed,proto3" json:"reversed,omitempty"`
+	// Cursor opaco retornado pela página anterior
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountBalancesRequest) Reset() {
+	*x = GetAccountBalancesRequest{}
+	mi := &file_proto_financial_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountBalancesRequest) ProtoMessage() {}
+
+func (x *GetAccountBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountBalancesRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetAccountBalancesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetAccountBalancesRequest) GetDirection() TransferDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return TransferDirection_TRANSFER_DIRECTION_BOTH
+}
+
+func (x *GetAccountBalancesRequest) GetTimestampMin() uint64 {
+	if x != nil {
+		return x.TimestampMin
+	}
+	return 0
+}
+
+func (x *GetAccountBalancesRequest) GetTimestampMax() uint64 {
+	if x != nil {
+		return x.TimestampMax
+	}
+	return 0
+}
+
+func (x *GetAccountBalancesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAccountBalancesRequest) GetReversed() bool {
+	if x != nil {
+		return x.Reversed
+	}
+	return false
+}
+
+func (x *GetAccountBalancesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Página de saldos históricos
+type GetAccountBalancesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Balances []*AccountBalance      `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	// Cursor da próxima página (vazio quando não há mais resultados)
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountBalancesResponse) Reset() {
+	*x = GetAccountBalancesResponse{}
+	mi := &file_proto_financial_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountBalancesResponse) ProtoMessage() {}
+
+func (x *GetAccountBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountBalancesResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetAccountBalancesResponse) GetBalances() []*AccountBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *GetAccountBalancesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Requisição do saldo de uma conta em um timestamp
+type GetBalanceAtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Timestamp     uint64                 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceAtRequest) Reset() {
+	*x = GetBalanceAtRequest{}
+	mi := &file_proto_financial_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceAtRequest) ProtoMessage() {}
+
+func (x *GetBalanceAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceAtRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceAtRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetBalanceAtRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetBalanceAtRequest) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Requisição de consulta de contas. Campos zerados não filtram; os demais
+// precisam coincidir todos
+type QueryAccountsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Decimal ou UUID
+	UserData_128 string `protobuf:"bytes,1,opt,name=user_data_128,json=userData128,proto3" json:"user_data_128,omitempty"`
+	UserData_64  uint64 `protobuf:"varint,2,opt,name=user_data_64,json=userData64,proto3" json:"user_data_64,omitempty"`
+	UserData_32  uint32 `protobuf:"varint,3,opt,name=user_data_32,json=userData32,proto3" json:"user_data_32,omitempty"`
+	Ledger       uint32 `protobuf:"varint,4,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Code         uint32 `protobuf:"varint,5,opt,name=code,proto3" json:"code,omitempty"`
+	// Intervalo de timestamps de criação (inclusivo, 0 = sem limite)
+	TimestampMin uint64 `protobuf:"varint,6,opt,name=timestamp_min,json=timestampMin,proto3" json:"timestamp_min,omitempty"`
+	TimestampMax uint64 `protobuf:"varint,7,opt,name=timestamp_max,json=timestampMax,proto3" json:"timestamp_max,omitempty"`
+	// Tamanho da página (padrão 50)
+	Limit uint32 `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Ordena do mais recente para o mais antigo
+	Reversed bool `protobuf:"varint,9,opt,name=reversed,proto3" json:"reversed,omitempty"`
+	// Cursor opaco retornado pela página anterior
+	PageToken     string `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAccountsRequest) Reset() {
+	*x = QueryAccountsRequest{}
+	mi := &file_proto_financial_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAccountsRequest) ProtoMessage() {}
+
+func (x *QueryAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAccountsRequest.ProtoReflect.Descriptor instead.
+func (*QueryAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *QueryAccountsRequest) GetUserData_128() string {
+	if x != nil {
+		return x.UserData_128
+	}
+	return ""
+}
+
+func (x *QueryAccountsRequest) GetUserData_64() uint64 {
+	if x != nil {
+		return x.UserData_64
+	}
+	return 0
+}
+
+func (x *QueryAccountsRequest) GetUserData_32() uint32 {
+	if x != nil {
+		return x.UserData_32
+	}
+	return 0
+}
+
+func (x *QueryAccountsRequest) GetLedger() uint32 {
+	if x != nil {
+		return x.Ledger
+	}
+	return 0
+}
+
+func (x *QueryAccountsRequest) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *QueryAccountsRequest) GetTimestampMin() uint64 {
+	if x != nil {
+		return x.TimestampMin
+	}
+	return 0
+}
+
+func (x *QueryAccountsRequest) GetTimestampMax() uint64 {
+	if x != nil {
+		return x.TimestampMax
+	}
+	return 0
+}
+
+func (x *QueryAccountsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QueryAccountsRequest) GetReversed() bool {
+	if x != nil {
+		return x.Reversed
+	}
+	return false
+}
+
+func (x *QueryAccountsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type QueryAccountsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Accounts []*AccountResponse     `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	// Cursor da próxima página (vazio quando não há mais resultados)
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAccountsResponse) Reset() {
+	*x = QueryAccountsResponse{}
+	mi := &file_proto_financial_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAccountsResponse) ProtoMessage() {}
+
+func (x *QueryAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAccountsResponse.ProtoReflect.Descriptor instead.
+func (*QueryAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *QueryAccountsResponse) GetAccounts() []*AccountResponse {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *QueryAccountsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Requisição de consulta de transferências, com os mesmos filtros da
+// consulta de contas
+type QueryTransfersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Decimal ou UUID
+	UserData_128 string `protobuf:"bytes,1,opt,name=user_data_128,json=userData128,proto3" json:"user_data_128,omitempty"`
+	UserData_64  uint64 `protobuf:"varint,2,opt,name=user_data_64,json=userData64,proto3" json:"user_data_64,omitempty"`
+	UserData_32  uint32 `protobuf:"varint,3,opt,name=user_data_32,json=userData32,proto3" json:"user_data_32,omitempty"`
+	Ledger       uint32 `protobuf:"varint,4,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Code         uint32 `protobuf:"varint,5,opt,name=code,proto3" json:"code,omitempty"`
+	// Intervalo de timestamps (inclusivo, 0 = sem limite)
+	TimestampMin uint64 `protobuf:"varint,6,opt,name=timestamp_min,json=timestampMin,proto3" json:"timestamp_min,omitempty"`
+	TimestampMax uint64 `protobuf:"varint,7,opt,name=timestamp_max,json=timestampMax,proto3" json:"timestamp_max,omitempty"`
+	// Tamanho da página (padrão 50)
+	Limit uint32 `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Ordena do mais recente para o mais antigo
+	Reversed bool `protobuf:"varint,9,opt,name=reversed,proto3" json:"reversed,omitempty"`
+	// Cursor opaco retornado pela página anterior
+	PageToken     string `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryTransfersRequest) Reset() {
+	*x = QueryTransfersRequest{}
+	mi := &file_proto_financial_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryTransfersRequest) ProtoMessage() {}
+
+func (x *QueryTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryTransfersRequest.ProtoReflect.Descriptor instead.
+func (*QueryTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QueryTransfersRequest) GetUserData_128() string {
+	if x != nil {
+		return x.UserData_128
+	}
+	return ""
+}
+
+func (x *QueryTransfersRequest) GetUserData_64() uint64 {
+	if x != nil {
+		return x.UserData_64
+	}
+	return 0
+}
+
+func (x *QueryTransfersRequest) GetUserData_32() uint32 {
+	if x != nil {
+		return x.UserData_32
+	}
+	return 0
+}
+
+func (x *QueryTransfersRequest) GetLedger() uint32 {
+	if x != nil {
+		return x.Ledger
+	}
+	return 0
+}
+
+func (x *QueryTransfersRequest) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *QueryTransfersRequest) GetTimestampMin() uint64 {
+	if x != nil {
+		return x.TimestampMin
+	}
+	return 0
+}
+
+func (x *QueryTransfersRequest) GetTimestampMax() uint64 {
+	if x != nil {
+		return x.TimestampMax
+	}
+	return 0
+}
+
+func (x *QueryTransfersRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QueryTransfersRequest) GetReversed() bool {
+	if x != nil {
+		return x.Reversed
+	}
+	return false
+}
+
+func (x *QueryTransfersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type QueryTransfersResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Transfers []*TransferResponse    `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	// Cursor da próxima página (vazio quando não há mais resultados)
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryTransfersResponse) Reset() {
+	*x = QueryTransfersResponse{}
+	mi := &file_proto_financial_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryTransfersResponse) ProtoMessage() {}
+
+func (x *QueryTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryTransfersResponse.ProtoReflect.Descriptor instead.
+func (*QueryTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *QueryTransfersResponse) GetTransfers() []*TransferResponse {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *QueryTransfersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Conta a importar. O id é obrigatório e os timestamps precisam ser
+// estritamente crescentes ao longo do stream
+type ImportAccountRecord struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Account *CreateAccountRequest  `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Timestamp original em nanossegundos
+	Timestamp     uint64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAccountRecord) Reset() {
+	*x = ImportAccountRecord{}
+	mi := &file_proto_financial_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAccountRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAccountRecord) ProtoMessage() {}
+
+func (x *ImportAccountRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAccountRecord.ProtoReflect.Descriptor instead.
+func (*ImportAccountRecord) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ImportAccountRecord) GetAccount() *CreateAccountRequest {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *ImportAccountRecord) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Transferência a importar, com as mesmas regras de ImportAccountRecord.
+// Transferências importadas não podem ter timeout
+type ImportTransferRecord struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Transfer *CreateTransferRequest `protobuf:"bytes,1,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	// Timestamp original em nanossegundos
+	Timestamp     uint64 `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTransferRecord) Reset() {
+	*x = ImportTransferRecord{}
+	mi := &file_proto_financial_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTransferRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTransferRecord) ProtoMessage() {}
+
+func (x *ImportTransferRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTransferRecord.ProtoReflect.Descriptor instead.
+func (*ImportTransferRecord) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportTransferRecord) GetTransfer() *CreateTransferRequest {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *ImportTransferRecord) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Registro rejeitado em uma importação
+type ImportFailure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Posição do registro no stream, a partir de 0
+	Index     uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id        string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp uint64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Resultado do TigerBeetle (ex.: EXCEEDS_CREDITS), VALIDATION_FAILED ou
+	// TIMESTAMP_NOT_INCREASING
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportFailure) Reset() {
+	*x = ImportFailure{}
+	mi := &file_proto_financial_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFailure) ProtoMessage() {}
+
+func (x *ImportFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFailure.ProtoReflect.Descriptor instead.
+func (*ImportFailure) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportFailure) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportFailure) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportFailure) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ImportFailure) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ImportFailure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Resumo de uma importação
+type ImportSummary struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Received uint64                 `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	Imported uint64                 `protobuf:"varint,2,opt,name=imported,proto3" json:"imported,omitempty"`
+	// Registros gravados por uma tentativa anterior, ao retomar
+	AlreadyImported uint64 `protobuf:"varint,3,opt,name=already_imported,json=alreadyImported,proto3" json:"already_imported,omitempty"`
+	Failed          uint64 `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Timestamp do último registro gravado
+	LastCommittedTimestamp uint64 `protobuf:"varint,5,opt,name=last_committed_timestamp,json=lastCommittedTimestamp,proto3" json:"last_committed_timestamp,omitempty"`
+	// Até 1000 falhas; failed tem o total
+	Failures      []*ImportFailure `protobuf:"bytes,6,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSummary) Reset() {
+	*x = ImportSummary{}
+	mi := &file_proto_financial_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSummary) ProtoMessage() {}
+
+func (x *ImportSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSummary.ProtoReflect.Descriptor instead.
+func (*ImportSummary) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportSummary) GetReceived() uint64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *ImportSummary) GetImported() uint64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportSummary) GetAlreadyImported() uint64 {
+	if x != nil {
+		return x.AlreadyImported
+	}
+	return 0
+}
+
+func (x *ImportSummary) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportSummary) GetLastCommittedTimestamp() uint64 {
+	if x != nil {
+		return x.LastCommittedTimestamp
+	}
+	return 0
+}
+
+func (x *ImportSummary) GetFailures() []*ImportFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type GetImportCheckpointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          ImportKind             `protobuf:"varint,1,opt,name=kind,proto3,enum=financial.ImportKind" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportCheckpointRequest) Reset() {
+	*x = GetImportCheckpointRequest{}
+	mi := &file_proto_financial_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportCheckpointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportCheckpointRequest) ProtoMessage() {}
+
+func (x *GetImportCheckpointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportCheckpointRequest.ProtoReflect.Descriptor instead.
+func (*GetImportCheckpointRequest) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetImportCheckpointRequest) GetKind() ImportKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ImportKind_IMPORT_KIND_UNSPECIFIED
+}
+
+// Ponto de retomada de uma importação
+type ImportCheckpoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Timestamp do registro importado mais recente do ledger: os registros com
+	// timestamp maior ainda precisam ser enviados (0 = nada importado)
+	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Timestamp do registro mais recente do ledger, importado ou não. O ledger
+	// só aceita registros importados mais novos que ele; se for maior que
+	// timestamp, houve escrita fora da importação e os registros restantes com
+	// timestamp até este valor serão rejeitados com
+	// IMPORTED_EVENT_TIMESTAMP_MUST_NOT_REGRESS
+	NewestTimestamp uint64 `protobuf:"varint,2,opt,name=newest_timestamp,json=newestTimestamp,proto3" json:"newest_timestamp,omitempty"`
+	// Registros lidos, do mais recente para trás, à procura de um importado
+	Scanned uint64 `protobuf:"varint,3,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	// A busca parou no limite de registros lidos sem achar um importado:
+	// timestamp 0 não significa então "nada importado"
+	ScanLimitReached bool `protobuf:"varint,4,opt,name=scan_limit_reached,json=scanLimitReached,proto3" json:"scan_limit_reached,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImportCheckpoint) Reset() {
+	*x = ImportCheckpoint{}
+	mi := &file_proto_financial_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCheckpoint) ProtoMessage() {}
+
+func (x *ImportCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_financial_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCheckpoint.ProtoReflect.Descriptor instead.
+func (*ImportCheckpoint) Descriptor() ([]byte, []int) {
+	return file_proto_financial_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportCheckpoint) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ImportCheckpoint) GetNewestTimestamp() uint64 {
+	if x != nil {
+		return x.NewestTimestamp
+	}
+	return 0
+}
+
+func (x *ImportCheckpoint) GetScanned() uint64 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+func (x *ImportCheckpoint) GetScanLimitReached() bool {
+	if x != nil {
+		return x.ScanLimitReached
+	}
+	return false
+}
+
+var File_proto_financial_proto protoreflect.FileDescriptor
+
+const file_proto_financial_proto_rawDesc = "" +
+	"\n" +
+	"\x15proto/financial.proto\x12\tfinancial\"\xe1\x01\n" +
+	"\x14CreateAccountRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\rR\x04code\x12\x16\n" +
+	"\x06ledger\x18\x02 \x01(\rR\x06ledger\x12\x14\n" +
+	"\x05flags\x18\x03 \x01(\rR\x05flags\x12\x0e\n" +
+	"\x02id\x18\x05 \x01(\tR\x02id\x12\"\n" +
+	"\ruser_data_128\x18\x06 \x01(\tR\vuserData128\x12 \n" +
+	"\fuser_data_64\x18\a \x01(\x04R\n" +
+	"userData64\x12 \n" +
+	"\fuser_data_32\x18\b \x01(\rR\n" +
+	"userData32J\x04\b\x04\x10\x05R\tuser_data\"#\n" +
+	"\x11GetAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd7\x03\n" +
+	"\x0fAccountResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\rR\x04code\x12\x16\n" +
+	"\x06ledger\x18\x03 \x01(\rR\x06ledger\x12\x14\n" +
+	"\x05flags\x18\x05 \x01(\rR\x05flags\x12\x18\n" +
+	"\asuccess\x18\a \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x12\x18\n" +
+	"\abalance\x18\t \x01(\tR\abalance\x12%\n" +
+	"\x0edebits_pending\x18\n" +
+	" \x01(\tR\rdebitsPending\x12#\n" +
+	"\rdebits_posted\x18\v \x01(\tR\fdebitsPosted\x12'\n" +
+	"\x0fcredits_pending\x18\f \x01(\tR\x0ecreditsPending\x12%\n" +
+	"\x0ecredits_posted\x18\r \x01(\tR\rcreditsPosted\x12\"\n" +
+	"\ruser_data_128\x18\x0e \x01(\tR\vuserData128\x12 \n" +
+	"\fuser_data_64\x18\x0f \x01(\x04R\n" +
+	"userData64\x12 \n" +
+	"\fuser_data_32\x18\x10 \x01(\rR\n" +
+	"userData32J\x04\b\x04\x10\x05J\x04\b\x06\x10\aR\tuser_data\"\xfe\x02\n" +
+	"\x15CreateTransferRequest\x12(\n" +
+	"\x10debit_account_id\x18\x01 \x01(\tR\x0edebitAccountId\x12*\n" +
+	"\x11credit_account_id\x18\x02 \x01(\tR\x0fcreditAccountId\x12\x16\n" +
+	"\x06ledger\x18\x04 \x01(\rR\x06ledger\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x14\n" +
+	"\x05flags\x18\x06 \x01(\rR\x05flags\x12\x1d\n" +
+	"\n" +
+	"pending_id\x18\a \x01(\tR\tpendingId\x12\x18\n" +
+	"\atimeout\x18\b \x01(\rR\atimeout\x12\x0e\n" +
+	"\x02id\x18\t \x01(\tR\x02id\x12\x16\n" +
+	"\x06amount\x18\n" +
+	" \x01(\tR\x06amount\x12\"\n" +
+	"\ruser_data_128\x18\v \x01(\tR\vuserData128\x12 \n" +
+	"\fuser_data_64\x18\f \x01(\x04R\n" +
+	"userData64\x12 \n" +
+	"\fuser_data_32\x18\r \x01(\rR\n" +
+	"userData32J\x04\b\x03\x10\x04\"$\n" +
+	"\x12GetTransferRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd6\x03\n" +
+	"\x10TransferResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10debit_account_id\x18\x02 \x01(\tR\x0edebitAccountId\x12*\n" +
+	"\x11credit_account_id\x18\x03 \x01(\tR\x0fcreditAccountId\x12\x16\n" +
+	"\x06ledger\x18\x05 \x01(\rR\x06ledger\x12\x12\n" +
+	"\x04code\x18\x06 \x01(\tR\x04code\x12\x14\n" +
+	"\x05flags\x18\a \x01(\rR\x05flags\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x04R\ttimestamp\x12\x18\n" +
+	"\asuccess\x18\t \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\n" +
+	" \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"pending_id\x18\v \x01(\tR\tpendingId\x12\x18\n" +
+	"\atimeout\x18\f \x01(\rR\atimeout\x12\x16\n" +
+	"\x06amount\x18\r \x01(\tR\x06amount\x12\"\n" +
+	"\ruser_data_128\x18\x0e \x01(\tR\vuserData128\x12 \n" +
+	"\fuser_data_64\x18\x0f \x01(\x04R\n" +
+	"userData64\x12 \n" +
+	"\fuser_data_32\x18\x10 \x01(\rR\n" +
+	"userData32J\x04\b\x04\x10\x05\"\xc7\x02\n" +
+	"\x13ReserveFundsRequest\x12(\n" +
+	"\x10debit_account_id\x18\x01 \x01(\tR\x0edebitAccountId\x12*\n" +
+	"\x11credit_account_id\x18\x02 \x01(\tR\x0fcreditAccountId\x12\x16\n" +
+	"\x06ledger\x18\x04 \x01(\rR\x06ledger\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x18\n" +
+	"\atimeout\x18\x06 \x01(\rR\atimeout\x12\x0e\n" +
+	"\x02id\x18\a \x01(\tR\x02id\x12\x16\n" +
+	"\x06amount\x18\b \x01(\tR\x06amount\x12\"\n" +
+	"\ruser_data_128\x18\t \x01(\tR\vuserData128\x12 \n" +
+	"\fuser_data_64\x18\n" +
+	" \x01(\x04R\n" +
+	"userData64\x12 \n" +
+	"\fuser_data_32\x18\v \x01(\rR\n" +
+	"userData32J\x04\b\x03\x10\x04\"d\n" +
+	"\x15CapturePendingRequest\x12\x1d\n" +
+	"\n" +
+	"pending_id\x18\x01 \x01(\tR\tpendingId\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amountJ\x04\b\x02\x10\x03\"C\n" +
+	"\x12VoidPendingRequest\x12\x1d\n" +
+	"\n" +
+	"pending_id\x18\x01 \x01(\tR\tpendingId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xbb\x02\n" +
+	"\vTransferLeg\x12(\n" +
+	"\x10debit_account_id\x18\x01 \x01(\tR\x0edebitAccountId\x12*\n" +
+	"\x11credit_account_id\x18\x02 \x01(\tR\x0fcreditAccountId\x12\x16\n" +
+	"\x06ledger\x18\x04 \x01(\rR\x06ledger\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x14\n" +
+	"\x05flags\x18\x06 \x01(\rR\x05flags\x12\x0e\n" +
+	"\x02id\x18\a \x01(\tR\x02id\x12\x16\n" +
+	"\x06amount\x18\b \x01(\tR\x06amount\x12\"\n" +
+	"\ruser_data_128\x18\t \x01(\tR\vuserData128\x12 \n" +
+	"\fuser_data_64\x18\n" +
+	" \x01(\x04R\n" +
+	"userData64\x12 \n" +
+	"\fuser_data_32\x18\v \x01(\rR\n" +
+	"userData32J\x04\b\x03\x10\x04\"J\n" +
+	"\x1cCreateLinkedTransfersRequest\x12*\n" +
+	"\x04legs\x18\x01 \x03(\v2\x16.financial.TransferLegR\x04legs\"r\n" +
+	"\x11TransferLegResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1f\n" +
+	"\vresult_code\x18\x03 \x01(\rR\n" +
+	"resultCode\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\"\xb3\x01\n" +
+	"\x17LinkedTransfersResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.financial.TransferLegResultR\aresults\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12!\n" +
+	"\ffailed_index\x18\x03 \x01(\x05R\vfailedIndex\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"Y\n" +
+	"\x1aCreateAccountsBatchRequest\x12;\n" +
+	"\baccounts\x18\x01 \x03(\v2\x1f.financial.CreateAccountRequestR\baccounts\"]\n" +
+	"\x1bCreateTransfersBatchRequest\x12>\n" +
+	"\ttransfers\x18\x01 \x03(\v2 .financial.CreateTransferRequestR\ttransfers\"\x95\x01\n" +
+	"\x0fBatchItemResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1f\n" +
+	"\vresult_code\x18\x03 \x01(\rR\n" +
+	"resultCode\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\x12#\n" +
+	"\rnot_attempted\x18\x05 \x01(\bR\fnotAttempted\"\xea\x01\n" +
+	"\rBatchResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.financial.BatchItemResultR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\rR\acreated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\rR\x06failed\x12'\n" +
+	"\x0falready_existed\x18\x04 \x01(\rR\x0ealreadyExisted\x12#\n" +
+	"\rnot_attempted\x18\x05 \x01(\rR\fnotAttempted\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\"\x93\x02\n" +
+	"\x1bListAccountTransfersRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12:\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x1c.financial.TransferDirectionR\tdirection\x12#\n" +
+	"\rtimestamp_min\x18\x03 \x01(\x04R\ftimestampMin\x12#\n" +
+	"\rtimestamp_max\x18\x04 \x01(\x04R\ftimestampMax\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\rR\x05limit\x12\x1a\n" +
+	"\breversed\x18\x06 \x01(\bR\breversed\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"\x81\x01\n" +
+	"\x1cListAccountTransfersResponse\x129\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x1b.financial.TransferResponseR\ttransfers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd0\x01\n" +
+	"\x0eAccountBalance\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x04R\ttimestamp\x12%\n" +
+	"\x0edebits_pending\x18\x06 \x01(\tR\rdebitsPending\x12#\n" +
+	"\rdebits_posted\x18\a \x01(\tR\fdebitsPosted\x12'\n" +
+	"\x0fcredits_pending\x18\b \x01(\tR\x0ecreditsPending\x12%\n" +
+	"\x0ecredits_posted\x18\t \x01(\tR\rcreditsPostedJ\x04\b\x01\x10\x05\"\x91\x02\n" +
+	"\x19GetAccountBalancesRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12:\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x1c.financial.TransferDirectionR\tdirection\x12#\n" +
+	"\rtimestamp_min\x18\x03 \x01(\x04R\ftimestampMin\x12#\n" +
+	"\rtimestamp_max\x18\x04 \x01(\x04R\ftimestampMax\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\rR\x05limit\x12\x1a\n" +
+	"\breversed\x18\x06 \x01(\bR\breversed\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"{\n" +
+	"\x1aGetAccountBalancesResponse\x125\n" +
+	"\bbalances\x18\x01 \x03(\v2\x19.financial.AccountBalanceR\bbalances\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"R\n" +
+	"\x13GetBalanceAtRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x04R\ttimestamp\"\xc5\x02\n" +
+	"\x14QueryAccountsRequest\x12\"\n" +
+	"\ruser_data_128\x18\x01 \x01(\tR\vuserData128\x12 \n" +
+	"\fuser_data_64\x18\x02 \x01(\x04R\n" +
+	"userData64\x12 \n" +
+	"\fuser_data_32\x18\x03 \x01(\rR\n" +
+	"userData32\x12\x16\n" +
+	"\x06ledger\x18\x04 \x01(\rR\x06ledger\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\rR\x04code\x12#\n" +
+	"\rtimestamp_min\x18\x06 \x01(\x04R\ftimestampMin\x12#\n" +
+	"\rtimestamp_max\x18\a \x01(\x04R\ftimestampMax\x12\x14\n" +
+	"\x05limit\x18\b \x01(\rR\x05limit\x12\x1a\n" +
+	"\breversed\x18\t \x01(\bR\breversed\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\n" +
+	" \x01(\tR\tpageToken\"w\n" +
+	"\x15QueryAccountsResponse\x126\n" +
+	"\baccounts\x18\x01 \x03(\v2\x1a.financial.AccountResponseR\baccounts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc6\x02\n" +
+	"\x15QueryTransfersRequest\x12\"\n" +
+	"\ruser_data_128\x18\x01 \x01(\tR\vuserData128\x12 \n" +
+	"\fuser_data_64\x18\x02 \x01(\x04R\n" +
+	"userData64\x12 \n" +
+	"\fuser_data_32\x18\x03 \x01(\rR\n" +
+	"userData32\x12\x16\n" +
+	"\x06ledger\x18\x04 \x01(\rR\x06ledger\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\rR\x04code\x12#\n" +
+	"\rtimestamp_min\x18\x06 \x01(\x04R\ftimestampMin\x12#\n" +
+	"\rtimestamp_max\x18\a \x01(\x04R\ftimestampMax\x12\x14\n" +
+	"\x05limit\x18\b \x01(\rR\x05limit\x12\x1a\n" +
+	"\breversed\x18\t \x01(\bR\breversed\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\n" +
+	" \x01(\tR\tpageToken\"{\n" +
+	"\x16QueryTransfersResponse\x129\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x1b.financial.TransferResponseR\ttransfers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"n\n" +
+	"\x13ImportAccountRecord\x129\n" +
+	"\aaccount\x18\x01 \x01(\v2\x1f.financial.CreateAccountRequestR\aaccount\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x04R\ttimestamp\"r\n" +
+	"\x14ImportTransferRecord\x12<\n" +
+	"\btransfer\x18\x01 \x01(\v2 .financial.CreateTransferRequestR\btransfer\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\x04R\ttimestamp\"\x85\x01\n" +
+	"\rImportFailure\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x04R\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x04R\ttimestamp\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"\xfa\x01\n" +
+	"\rImportSummary\x12\x1a\n" +
+	"\breceived\x18\x01 \x01(\x04R\breceived\x12\x1a\n" +
+	"\bimported\x18\x02 \x01(\x04R\bimported\x12)\n" +
+	"\x10already_imported\x18\x03 \x01(\x04R\x0falreadyImported\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x04R\x06failed\x128\n" +
+	"\x18last_committed_timestamp\x18\x05 \x01(\x04R\x16lastCommittedTimestamp\x124\n" +
+	"\bfailures\x18\x06 \x03(\v2\x18.financial.ImportFailureR\bfailures\"G\n" +
+	"\x1aGetImportCheckpointRequest\x12)\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x15.financial.ImportKindR\x04kind\"\xa3\x01\n" +
+	"\x10ImportCheckpoint\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x04R\ttimestamp\x12)\n" +
+	"\x10newest_timestamp\x18\x02 \x01(\x04R\x0fnewestTimestamp\x12\x18\n" +
+	"\ascanned\x18\x03 \x01(\x04R\ascanned\x12,\n" +
+	"\x12scan_limit_reached\x18\x04 \x01(\bR\x10scanLimitReached*o\n" +
+	"\x11TransferDirection\x12\x1b\n" +
+	"\x17TRANSFER_DIRECTION_BOTH\x10\x00\x12\x1d\n" +
+	"\x19TRANSFER_DIRECTION_DEBITS\x10\x01\x12\x1e\n" +
+	"\x1aTRANSFER_DIRECTION_CREDITS\x10\x02*^\n" +
+	"\n" +
+	"ImportKind\x12\x1b\n" +
+	"\x17IMPORT_KIND_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14IMPORT_KIND_ACCOUNTS\x10\x01\x12\x19\n" +
+	"\x15IMPORT_KIND_TRANSFERS\x10\x022\x80\f\n" +
+	"\x10FinancialService\x12L\n" +
+	"\rCreateAccount\x12\x1f.financial.CreateAccountRequest\x1a\x1a.financial.AccountResponse\x12F\n" +
+	"\n" +
+	"GetAccount\x12\x1c.financial.GetAccountRequest\x1a\x1a.financial.AccountResponse\x12O\n" +
+	"\x0eCreateTransfer\x12 .financial.CreateTransferRequest\x1a\x1b.financial.TransferResponse\x12I\n" +
+	"\vGetTransfer\x12\x1d.financial.GetTransferRequest\x1a\x1b.financial.TransferResponse\x12K\n" +
+	"\fReserveFunds\x12\x1e.financial.ReserveFundsRequest\x1a\x1b.financial.TransferResponse\x12O\n" +
+	"\x0eCapturePending\x12 .financial.CapturePendingRequest\x1a\x1b.financial.TransferResponse\x12I\n" +
+	"\vVoidPending\x12\x1d.financial.VoidPendingRequest\x1a\x1b.financial.TransferResponse\x12d\n" +
+	"\x15CreateLinkedTransfers\x12'.financial.CreateLinkedTransfersRequest\x1a\".financial.LinkedTransfersResponse\x12V\n" +
+	"\x13CreateAccountsBatch\x12%.financial.CreateAccountsBatchRequest\x1a\x18.financial.BatchResponse\x12X\n" +
+	"\x14CreateTransfersBatch\x12&.financial.CreateTransfersBatchRequest\x1a\x18.financial.BatchResponse\x12g\n" +
+	"\x14ListAccountTransfers\x12&.financial.ListAccountTransfersRequest\x1a'.financial.ListAccountTransfersResponse\x12a\n" +
+	"\x12GetAccountBalances\x12$.financial.GetAccountBalancesRequest\x1a%.financial.GetAccountBalancesResponse\x12I\n" +
+	"\fGetBalanceAt\x12\x1e.financial.GetBalanceAtRequest\x1a\x19.financial.AccountBalance\x12R\n" +
+	"\rQueryAccounts\x12\x1f.financial.QueryAccountsRequest\x1a .financial.QueryAccountsResponse\x12U\n" +
+	"\x0eQueryTransfers\x12 .financial.QueryTransfersRequest\x1a!.financial.QueryTransfersResponse\x12L\n" +
+	"\x0eImportAccounts\x12\x1e.financial.ImportAccountRecord\x1a\x18.financial.ImportSummary(\x01\x12N\n" +
+	"\x0fImportTransfers\x12\x1f.financial.ImportTransferRecord\x1a\x18.financial.ImportSummary(\x01\x12Y\n" +
+	"\x13GetImportCheckpoint\x12%.financial.GetImportCheckpointRequest\x1a\x1b.financial.ImportCheckpointB\x1bZ\x19tigerbeetle-service/protob\x06proto3"
+
+var (
+	file_proto_financial_proto_rawDescOnce sync.Once
+	file_proto_financial_proto_rawDescData []byte
+)
+
+func file_proto_financial_proto_rawDescGZIP() []byte {
+	file_proto_financial_proto_rawDescOnce.Do(func() {
+		file_proto_financial_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_financial_proto_rawDesc), len(file_proto_financial_proto_rawDesc)))
+	})
+	return file_proto_financial_proto_rawDescData
+}
+
+var file_proto_financial_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_financial_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_proto_financial_proto_goTypes = []any{
+	(TransferDirection)(0),               // 0: financial.TransferDirection
+	(ImportKind)(0),                      // 1: financial.ImportKind
+	(*CreateAccountRequest)(nil),         // 2: financial.CreateAccountRequest
+	(*GetAccountRequest)(nil),            // 3: financial.GetAccountRequest
+	(*AccountResponse)(nil),              // 4: financial.AccountResponse
+	(*CreateTransferRequest)(nil),        // 5: financial.CreateTransferRequest
+	(*GetTransferRequest)(nil),           // 6: financial.GetTransferRequest
+	(*TransferResponse)(nil),             // 7: financial.TransferResponse
+	(*ReserveFundsRequest)(nil),          // 8: financial.ReserveFundsRequest
+	(*CapturePendingRequest)(nil),        // 9: financial.CapturePendingRequest
+	(*VoidPendingRequest)(nil),           // 10: financial.VoidPendingRequest
+	(*TransferLeg)(nil),                  // 11: financial.TransferLeg
+	(*CreateLinkedTransfersRequest)(nil), // 12: financial.CreateLinkedTransfersRequest
+	(*TransferLegResult)(nil),            // 13: financial.TransferLegResult
+	(*LinkedTransfersResponse)(nil),      // 14: financial.LinkedTransfersResponse
+	(*CreateAccountsBatchRequest)(nil),   // 15: financial.CreateAccountsBatchRequest
+	(*CreateTransfersBatchRequest)(nil),  // 16: financial.CreateTransfersBatchRequest
+	(*BatchItemResult)(nil),              // 17: financial.BatchItemResult
+	(*BatchResponse)(nil),                // 18: financial.BatchResponse
+	(*ListAccountTransfersRequest)(nil),  // 19: financial.ListAccountTransfersRequest
+	(*ListAccountTransfersResponse)(nil), // 20: financial.ListAccountTransfersResponse
+	(*AccountBalance)(nil),               // 21: financial.AccountBalance
+	(*GetAccountBalancesRequest)(nil),    // 22: financial.GetAccountBalancesRequest
+	(*GetAccountBalancesResponse)(nil),   // 23: financial.GetAccountBalancesResponse
+	(*GetBalanceAtRequest)(nil),          // 24: financial.GetBalanceAtRequest
+	(*QueryAccountsRequest)(nil),         // 25: financial.QueryAccountsRequest
+	(*QueryAccountsResponse)(nil),        // 26: financial.QueryAccountsResponse
+	(*QueryTransfersRequest)(nil),        // 27: financial.QueryTransfersRequest
+	(*QueryTransfersResponse)(nil),       // 28: financial.QueryTransfersResponse
+	(*ImportAccountRecord)(nil),          // 29: financial.ImportAccountRecord
+	(*ImportTransferRecord)(nil),         // 30: financial.ImportTransferRecord
+	(*ImportFailure)(nil),                // 31: financial.ImportFailure
+	(*ImportSummary)(nil),                // 32: financial.ImportSummary
+	(*GetImportCheckpointRequest)(nil),   // 33: financial.GetImportCheckpointRequest
+	(*ImportCheckpoint)(nil),             // 34: financial.ImportCheckpoint
+}
+var file_proto_financial_proto_depIdxs = []int32{
+	11, // 0: financial.CreateLinkedTransfersRequest.legs:type_name -> financial.TransferLeg
+	13, // 1: financial.LinkedTransfersResponse.results:type_name -> financial.TransferLegResult
+	2,  // 2: financial.CreateAccountsBatchRequest.accounts:type_name -> financial.CreateAccountRequest
+	5,  // 3: financial.CreateTransfersBatchRequest.transfers:type_name -> financial.CreateTransferRequest
+	17, // 4: financial.BatchResponse.results:type_name -> financial.BatchItemResult
+	0,  // 5: financial.ListAccountTransfersRequest.direction:type_name -> financial.TransferDirection
+	7,  // 6: financial.ListAccountTransfersResponse.transfers:type_name -> financial.TransferResponse
+	0,  // 7: financial.GetAccountBalancesRequest.direction:type_name -> financial.TransferDirection
+	21, // 8: financial.GetAccountBalancesResponse.balances:type_name -> financial.AccountBalance
+	4,  // 9: financial.QueryAccountsResponse.accounts:type_name -> financial.AccountResponse
+	7,  // 10: financial.QueryTransfersResponse.transfers:type_name -> financial.TransferResponse
+	2,  // 11: financial.ImportAccountRecord.account:type_name -> financial.CreateAccountRequest
+	5,  // 12: financial.ImportTransferRecord.transfer:type_name -> financial.CreateTransferRequest
+	31, // 13: financial.ImportSummary.failures:type_name -> financial.ImportFailure
+	1,  // 14: financial.GetImportCheckpointRequest.kind:type_name -> financial.ImportKind
+	2,  // 15: financial.FinancialService.CreateAccount:input_type -> financial.CreateAccountRequest
+	3,  // 16: financial.FinancialService.GetAccount:input_type -> financial.GetAccountRequest
+	5,  // 17: financial.FinancialService.CreateTransfer:input_type -> financial.CreateTransferRequest
+	6,  // 18: financial.FinancialService.GetTransfer:input_type -> financial.GetTransferRequest
+	8,  // 19: financial.FinancialService.ReserveFunds:input_type -> financial.ReserveFundsRequest
+	9,  // 20: financial.FinancialService.CapturePending:input_type -> financial.CapturePendingRequest
+	10, // 21: financial.FinancialService.VoidPending:input_type -> financial.VoidPendingRequest
+	12, // 22: financial.FinancialService.CreateLinkedTransfers:input_type -> financial.CreateLinkedTransfersRequest
+	15, // 23: financial.FinancialService.CreateAccountsBatch:input_type -> financial.CreateAccountsBatchRequest
+	16, // 24: financial.FinancialService.CreateTransfersBatch:input_type -> financial.CreateTransfersBatchRequest
+	19, // 25: financial.FinancialService.ListAccountTransfers:input_type -> financial.ListAccountTransfersRequest
+	22, // 26: financial.FinancialService.GetAccountBalances:input_type -> financial.GetAccountBalancesRequest
+	24, // 27: financial.FinancialService.GetBalanceAt:input_type -> financial.GetBalanceAtRequest
+	25, // 28: financial.FinancialService.QueryAccounts:input_type -> financial.QueryAccountsRequest
+	27, // 29: financial.FinancialService.QueryTransfers:input_type -> financial.QueryTransfersRequest
+	29, // 30: financial.FinancialService.ImportAccounts:input_type -> financial.ImportAccountRecord
+	30, // 31: financial.FinancialService.ImportTransfers:input_type -> financial.ImportTransferRecord
+	33, // 32: financial.FinancialService.GetImportCheckpoint:input_type -> financial.GetImportCheckpointRequest
+	4,  // 33: financial.FinancialService.CreateAccount:output_type -> financial.AccountResponse
+	4,  // 34: financial.FinancialService.GetAccount:output_type -> financial.AccountResponse
+	7,  // 35: financial.FinancialService.CreateTransfer:output_type -> financial.TransferResponse
+	7,  // 36: financial.FinancialService.GetTransfer:output_type -> financial.TransferResponse
+	7,  // 37: financial.FinancialService.ReserveFunds:output_type -> financial.TransferResponse
+	7,  // 38: financial.FinancialService.CapturePending:output_type -> financial.TransferResponse
+	7,  // 39: financial.FinancialService.VoidPending:output_type -> financial.TransferResponse
+	14, // 40: financial.FinancialService.CreateLinkedTransfers:output_type -> financial.LinkedTransfersResponse
+	18, // 41: financial.FinancialService.CreateAccountsBatch:output_type -> financial.BatchResponse
+	18, // 42: financial.FinancialService.CreateTransfersBatch:output_type -> financial.BatchResponse
+	20, // 43: financial.FinancialService.ListAccountTransfers:output_type -> financial.ListAccountTransfersResponse
+	23, // 44: financial.FinancialService.GetAccountBalances:output_type -> financial.GetAccountBalancesResponse
+	21, // 45: financial.FinancialService.GetBalanceAt:output_type -> financial.AccountBalance
+	26, // 46: financial.FinancialService.QueryAccounts:output_type -> financial.QueryAccountsResponse
+	28, // 47: financial.FinancialService.QueryTransfers:output_type -> financial.QueryTransfersResponse
+	32, // 48: financial.FinancialService.ImportAccounts:output_type -> financial.ImportSummary
+	32, // 49: financial.FinancialService.ImportTransfers:output_type -> financial.ImportSummary
+	34, // 50: financial.FinancialService.GetImportCheckpoint:output_type -> financial.ImportCheckpoint
+	33, // [33:51] is the sub-list for method output_type
+	15, // [15:33] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_proto_financial_proto_init() }
+func file_proto_financial_proto_init() {
+	if File_proto_financial_proto != nil {
+		return
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_proto_financial_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_financial_proto_rawDesc), len(file_proto_financial_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_financial_proto_goTypes,
 		DependencyIndexes: file_proto_financial_proto_depIdxs,
+		EnumInfos:         file_proto_financial_proto_enumTypes,
 		MessageInfos:      file_proto_financial_proto_msgTypes,
 	}.Build()
 	File_proto_financial_proto = out.File
-	file_proto_financial_proto_rawDesc = nil
 	file_proto_financial_proto_goTypes = nil
 	file_proto_financial_proto_depIdxs = nil
 }
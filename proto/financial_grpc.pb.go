@@ -1,4 +1,10 @@
+// proto/financial.proto
+
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: proto/financial.proto
 
 package proto
 
@@ -11,12 +17,35 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.32.0 or later.
-const _ = grpc.SupportPackageIsVersion7
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FinancialService_CreateAccount_FullMethodName         = "/financial.FinancialService/CreateAccount"
+	FinancialService_GetAccount_FullMethodName            = "/financial.FinancialService/GetAccount"
+	FinancialService_CreateTransfer_FullMethodName        = "/financial.FinancialService/CreateTransfer"
+	FinancialService_GetTransfer_FullMethodName           = "/financial.FinancialService/GetTransfer"
+	FinancialService_ReserveFunds_FullMethodName          = "/financial.FinancialService/ReserveFunds"
+	FinancialService_CapturePending_FullMethodName        = "/financial.FinancialService/CapturePending"
+	FinancialService_VoidPending_FullMethodName           = "/financial.FinancialService/VoidPending"
+	FinancialService_CreateLinkedTransfers_FullMethodName = "/financial.FinancialService/CreateLinkedTransfers"
+	FinancialService_CreateAccountsBatch_FullMethodName   = "/financial.FinancialService/CreateAccountsBatch"
+	FinancialService_CreateTransfersBatch_FullMethodName  = "/financial.FinancialService/CreateTransfersBatch"
+	FinancialService_ListAccountTransfers_FullMethodName  = "/financial.FinancialService/ListAccountTransfers"
+	FinancialService_GetAccountBalances_FullMethodName    = "/financial.FinancialService/GetAccountBalances"
+	FinancialService_GetBalanceAt_FullMethodName          = "/financial.FinancialService/GetBalanceAt"
+	FinancialService_QueryAccounts_FullMethodName         = "/financial.FinancialService/QueryAccounts"
+	FinancialService_QueryTransfers_FullMethodName        = "/financial.FinancialService/QueryTransfers"
+	FinancialService_ImportAccounts_FullMethodName        = "/financial.FinancialService/ImportAccounts"
+	FinancialService_ImportTransfers_FullMethodName       = "/financial.FinancialService/ImportTransfers"
+	FinancialService_GetImportCheckpoint_FullMethodName   = "/financial.FinancialService/GetImportCheckpoint"
+)
 
 // FinancialServiceClient is the client API for FinancialService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Serviço principal para gerenciamento financeiro
 type FinancialServiceClient interface {
 	// Operações de conta
 	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error)
@@ -24,6 +53,28 @@ type FinancialServiceClient interface {
 	// Operações de transação
 	CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
 	GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	// Transferências em duas fases (reserva, captura e cancelamento)
+	ReserveFunds(ctx context.Context, in *ReserveFundsRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	CapturePending(ctx context.Context, in *CapturePendingRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	VoidPending(ctx context.Context, in *VoidPendingRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	// Transferências atômicas com múltiplas pernas (cadeia encadeada)
+	CreateLinkedTransfers(ctx context.Context, in *CreateLinkedTransfersRequest, opts ...grpc.CallOption) (*LinkedTransfersResponse, error)
+	// Operações em lote
+	CreateAccountsBatch(ctx context.Context, in *CreateAccountsBatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	CreateTransfersBatch(ctx context.Context, in *CreateTransfersBatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	// Histórico de transferências de uma conta, paginado
+	ListAccountTransfers(ctx context.Context, in *ListAccountTransfersRequest, opts ...grpc.CallOption) (*ListAccountTransfersResponse, error)
+	// Saldos históricos (apenas contas criadas com a flag history)
+	GetAccountBalances(ctx context.Context, in *GetAccountBalancesRequest, opts ...grpc.CallOption) (*GetAccountBalancesResponse, error)
+	GetBalanceAt(ctx context.Context, in *GetBalanceAtRequest, opts ...grpc.CallOption) (*AccountBalance, error)
+	// Consultas por user_data, ledger, código e período, paginadas
+	QueryAccounts(ctx context.Context, in *QueryAccountsRequest, opts ...grpc.CallOption) (*QueryAccountsResponse, error)
+	QueryTransfers(ctx context.Context, in *QueryTransfersRequest, opts ...grpc.CallOption) (*QueryTransfersResponse, error)
+	// Importação de histórico com os timestamps originais (flag imported).
+	// Após uma desconexão, GetImportCheckpoint informa de onde retomar
+	ImportAccounts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportAccountRecord, ImportSummary], error)
+	ImportTransfers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTransferRecord, ImportSummary], error)
+	GetImportCheckpoint(ctx context.Context, in *GetImportCheckpointRequest, opts ...grpc.CallOption) (*ImportCheckpoint, error)
 }
 
 type financialServiceClient struct {
@@ -35,8 +86,9 @@ func NewFinancialServiceClient(cc grpc.ClientConnInterface) FinancialServiceClie
 }
 
 func (c *financialServiceClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AccountResponse)
-	err := c.cc.Invoke(ctx, "/financial.FinancialService/CreateAccount", in, out, opts...)
+	err := c.cc.Invoke(ctx, FinancialService_CreateAccount_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -44,8 +96,9 @@ func (c *financialServiceClient) CreateAccount(ctx context.Context, in *CreateAc
 }
 
 func (c *financialServiceClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*AccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AccountResponse)
-	err := c.cc.Invoke(ctx, "/financial.FinancialService/GetAccount", in, out, opts...)
+	err := c.cc.Invoke(ctx, FinancialService_GetAccount_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +106,9 @@ func (c *financialServiceClient) GetAccount(ctx context.Context, in *GetAccountR
 }
 
 func (c *financialServiceClient) CreateTransfer(ctx context.Context, in *CreateTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TransferResponse)
-	err := c.cc.Invoke(ctx, "/financial.FinancialService/CreateTransfer", in, out, opts...)
+	err := c.cc.Invoke(ctx, FinancialService_CreateTransfer_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +116,155 @@ func (c *financialServiceClient) CreateTransfer(ctx context.Context, in *CreateT
 }
 
 func (c *financialServiceClient) GetTransfer(ctx context.Context, in *GetTransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, FinancialService_GetTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) ReserveFunds(ctx context.Context, in *ReserveFundsRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, FinancialService_ReserveFunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) CapturePending(ctx context.Context, in *CapturePendingRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, FinancialService_CapturePending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) VoidPending(ctx context.Context, in *VoidPendingRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TransferResponse)
-	err := c.cc.Invoke(ctx, "/financial.FinancialService/GetTransfer", in, out, opts...)
+	err := c.cc.Invoke(ctx, FinancialService_VoidPending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) CreateLinkedTransfers(ctx context.Context, in *CreateLinkedTransfersRequest, opts ...grpc.CallOption) (*LinkedTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkedTransfersResponse)
+	err := c.cc.Invoke(ctx, FinancialService_CreateLinkedTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) CreateAccountsBatch(ctx context.Context, in *CreateAccountsBatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, FinancialService_CreateAccountsBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) CreateTransfersBatch(ctx context.Context, in *CreateTransfersBatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, FinancialService_CreateTransfersBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) ListAccountTransfers(ctx context.Context, in *ListAccountTransfersRequest, opts ...grpc.CallOption) (*ListAccountTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountTransfersResponse)
+	err := c.cc.Invoke(ctx, FinancialService_ListAccountTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) GetAccountBalances(ctx context.Context, in *GetAccountBalancesRequest, opts ...grpc.CallOption) (*GetAccountBalancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccountBalancesResponse)
+	err := c.cc.Invoke(ctx, FinancialService_GetAccountBalances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) GetBalanceAt(ctx context.Context, in *GetBalanceAtRequest, opts ...grpc.CallOption) (*AccountBalance, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountBalance)
+	err := c.cc.Invoke(ctx, FinancialService_GetBalanceAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) QueryAccounts(ctx context.Context, in *QueryAccountsRequest, opts ...grpc.CallOption) (*QueryAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAccountsResponse)
+	err := c.cc.Invoke(ctx, FinancialService_QueryAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) QueryTransfers(ctx context.Context, in *QueryTransfersRequest, opts ...grpc.CallOption) (*QueryTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryTransfersResponse)
+	err := c.cc.Invoke(ctx, FinancialService_QueryTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *financialServiceClient) ImportAccounts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportAccountRecord, ImportSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FinancialService_ServiceDesc.Streams[0], FinancialService_ImportAccounts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportAccountRecord, ImportSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FinancialService_ImportAccountsClient = grpc.ClientStreamingClient[ImportAccountRecord, ImportSummary]
+
+func (c *financialServiceClient) ImportTransfers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTransferRecord, ImportSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FinancialService_ServiceDesc.Streams[1], FinancialService_ImportTransfers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportTransferRecord, ImportSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FinancialService_ImportTransfersClient = grpc.ClientStreamingClient[ImportTransferRecord, ImportSummary]
+
+func (c *financialServiceClient) GetImportCheckpoint(ctx context.Context, in *GetImportCheckpointRequest, opts ...grpc.CallOption) (*ImportCheckpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportCheckpoint)
+	err := c.cc.Invoke(ctx, FinancialService_GetImportCheckpoint_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +273,9 @@ func (c *financialServiceClient) GetTransfer(ctx context.Context, in *GetTransfe
 
 // FinancialServiceServer is the server API for FinancialService service.
 // All implementations must embed UnimplementedFinancialServiceServer
-// for forward compatibility
+// for forward compatibility.
+//
+// Serviço principal para gerenciamento financeiro
 type FinancialServiceServer interface {
 	// Operações de conta
 	CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error)
@@ -80,12 +283,37 @@ type FinancialServiceServer interface {
 	// Operações de transação
 	CreateTransfer(context.Context, *CreateTransferRequest) (*TransferResponse, error)
 	GetTransfer(context.Context, *GetTransferRequest) (*TransferResponse, error)
+	// Transferências em duas fases (reserva, captura e cancelamento)
+	ReserveFunds(context.Context, *ReserveFundsRequest) (*TransferResponse, error)
+	CapturePending(context.Context, *CapturePendingRequest) (*TransferResponse, error)
+	VoidPending(context.Context, *VoidPendingRequest) (*TransferResponse, error)
+	// Transferências atômicas com múltiplas pernas (cadeia encadeada)
+	CreateLinkedTransfers(context.Context, *CreateLinkedTransfersRequest) (*LinkedTransfersResponse, error)
+	// Operações em lote
+	CreateAccountsBatch(context.Context, *CreateAccountsBatchRequest) (*BatchResponse, error)
+	CreateTransfersBatch(context.Context, *CreateTransfersBatchRequest) (*BatchResponse, error)
+	// Histórico de transferências de uma conta, paginado
+	ListAccountTransfers(context.Context, *ListAccountTransfersRequest) (*ListAccountTransfersResponse, error)
+	// Saldos históricos (apenas contas criadas com a flag history)
+	GetAccountBalances(context.Context, *GetAccountBalancesRequest) (*GetAccountBalancesResponse, error)
+	GetBalanceAt(context.Context, *GetBalanceAtRequest) (*AccountBalance, error)
+	// Consultas por user_data, ledger, código e período, paginadas
+	QueryAccounts(context.Context, *QueryAccountsRequest) (*QueryAccountsResponse, error)
+	QueryTransfers(context.Context, *QueryTransfersRequest) (*QueryTransfersResponse, error)
+	// Importação de histórico com os timestamps originais (flag imported).
+	// Após uma desconexão, GetImportCheckpoint informa de onde retomar
+	ImportAccounts(grpc.ClientStreamingServer[ImportAccountRecord, ImportSummary]) error
+	ImportTransfers(grpc.ClientStreamingServer[ImportTransferRecord, ImportSummary]) error
+	GetImportCheckpoint(context.Context, *GetImportCheckpointRequest) (*ImportCheckpoint, error)
 	mustEmbedUnimplementedFinancialServiceServer()
 }
 
-// UnimplementedFinancialServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedFinancialServiceServer struct {
-}
+// UnimplementedFinancialServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFinancialServiceServer struct{}
 
 func (UnimplementedFinancialServiceServer) CreateAccount(context.Context, *CreateAccountRequest) (*AccountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateAccount not implemented")
@@ -99,7 +327,50 @@ func (UnimplementedFinancialServiceServer) CreateTransfer(context.Context, *Crea
 func (UnimplementedFinancialServiceServer) GetTransfer(context.Context, *GetTransferRequest) (*TransferResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransfer not implemented")
 }
+func (UnimplementedFinancialServiceServer) ReserveFunds(context.Context, *ReserveFundsRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveFunds not implemented")
+}
+func (UnimplementedFinancialServiceServer) CapturePending(context.Context, *CapturePendingRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CapturePending not implemented")
+}
+func (UnimplementedFinancialServiceServer) VoidPending(context.Context, *VoidPendingRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoidPending not implemented")
+}
+func (UnimplementedFinancialServiceServer) CreateLinkedTransfers(context.Context, *CreateLinkedTransfersRequest) (*LinkedTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLinkedTransfers not implemented")
+}
+func (UnimplementedFinancialServiceServer) CreateAccountsBatch(context.Context, *CreateAccountsBatchRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAccountsBatch not implemented")
+}
+func (UnimplementedFinancialServiceServer) CreateTransfersBatch(context.Context, *CreateTransfersBatchRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTransfersBatch not implemented")
+}
+func (UnimplementedFinancialServiceServer) ListAccountTransfers(context.Context, *ListAccountTransfersRequest) (*ListAccountTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccountTransfers not implemented")
+}
+func (UnimplementedFinancialServiceServer) GetAccountBalances(context.Context, *GetAccountBalancesRequest) (*GetAccountBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountBalances not implemented")
+}
+func (UnimplementedFinancialServiceServer) GetBalanceAt(context.Context, *GetBalanceAtRequest) (*AccountBalance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAt not implemented")
+}
+func (UnimplementedFinancialServiceServer) QueryAccounts(context.Context, *QueryAccountsRequest) (*QueryAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAccounts not implemented")
+}
+func (UnimplementedFinancialServiceServer) QueryTransfers(context.Context, *QueryTransfersRequest) (*QueryTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryTransfers not implemented")
+}
+func (UnimplementedFinancialServiceServer) ImportAccounts(grpc.ClientStreamingServer[ImportAccountRecord, ImportSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportAccounts not implemented")
+}
+func (UnimplementedFinancialServiceServer) ImportTransfers(grpc.ClientStreamingServer[ImportTransferRecord, ImportSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportTransfers not implemented")
+}
+func (UnimplementedFinancialServiceServer) GetImportCheckpoint(context.Context, *GetImportCheckpointRequest) (*ImportCheckpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImportCheckpoint not implemented")
+}
 func (UnimplementedFinancialServiceServer) mustEmbedUnimplementedFinancialServiceServer() {}
+func (UnimplementedFinancialServiceServer) testEmbeddedByValue()                          {}
 
 // UnsafeFinancialServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to FinancialServiceServer will
@@ -109,6 +380,13 @@ type UnsafeFinancialServiceServer interface {
 }
 
 func RegisterFinancialServiceServer(s grpc.ServiceRegistrar, srv FinancialServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFinancialServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
 	s.RegisterService(&FinancialService_ServiceDesc, srv)
 }
 
@@ -122,7 +400,7 @@ func _FinancialService_CreateAccount_Handler(srv interface{}, ctx context.Contex
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/financial.FinancialService/CreateAccount",
+		FullMethod: FinancialService_CreateAccount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(FinancialServiceServer).CreateAccount(ctx, req.(*CreateAccountRequest))
@@ -140,7 +418,7 @@ func _FinancialService_GetAccount_Handler(srv interface{}, ctx context.Context,
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/financial.FinancialService/GetAccount",
+		FullMethod: FinancialService_GetAccount_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(FinancialServiceServer).GetAccount(ctx, req.(*GetAccountRequest))
@@ -158,7 +436,7 @@ func _FinancialService_CreateTransfer_Handler(srv interface{}, ctx context.Conte
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/financial.FinancialService/CreateTransfer",
+		FullMethod: FinancialService_CreateTransfer_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(FinancialServiceServer).CreateTransfer(ctx, req.(*CreateTransferRequest))
@@ -176,7 +454,7 @@ func _FinancialService_GetTransfer_Handler(srv interface{}, ctx context.Context,
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/financial.FinancialService/GetTransfer",
+		FullMethod: FinancialService_GetTransfer_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(FinancialServiceServer).GetTransfer(ctx, req.(*GetTransferRequest))
@@ -184,6 +462,236 @@ func _FinancialService_GetTransfer_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FinancialService_ReserveFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).ReserveFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_ReserveFunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).ReserveFunds(ctx, req.(*ReserveFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_CapturePending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapturePendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).CapturePending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_CapturePending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).CapturePending(ctx, req.(*CapturePendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_VoidPending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidPendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).VoidPending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_VoidPending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).VoidPending(ctx, req.(*VoidPendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_CreateLinkedTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLinkedTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).CreateLinkedTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_CreateLinkedTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).CreateLinkedTransfers(ctx, req.(*CreateLinkedTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_CreateAccountsBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountsBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).CreateAccountsBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_CreateAccountsBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).CreateAccountsBatch(ctx, req.(*CreateAccountsBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_CreateTransfersBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransfersBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).CreateTransfersBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_CreateTransfersBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).CreateTransfersBatch(ctx, req.(*CreateTransfersBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_ListAccountTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).ListAccountTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_ListAccountTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).ListAccountTransfers(ctx, req.(*ListAccountTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_GetAccountBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).GetAccountBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_GetAccountBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).GetAccountBalances(ctx, req.(*GetAccountBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_GetBalanceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).GetBalanceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_GetBalanceAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).GetBalanceAt(ctx, req.(*GetBalanceAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_QueryAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).QueryAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_QueryAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).QueryAccounts(ctx, req.(*QueryAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_QueryTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).QueryTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_QueryTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).QueryTransfers(ctx, req.(*QueryTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinancialService_ImportAccounts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FinancialServiceServer).ImportAccounts(&grpc.GenericServerStream[ImportAccountRecord, ImportSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FinancialService_ImportAccountsServer = grpc.ClientStreamingServer[ImportAccountRecord, ImportSummary]
+
+func _FinancialService_ImportTransfers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FinancialServiceServer).ImportTransfers(&grpc.GenericServerStream[ImportTransferRecord, ImportSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FinancialService_ImportTransfersServer = grpc.ClientStreamingServer[ImportTransferRecord, ImportSummary]
+
+func _FinancialService_GetImportCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinancialServiceServer).GetImportCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinancialService_GetImportCheckpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinancialServiceServer).GetImportCheckpoint(ctx, req.(*GetImportCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FinancialService_ServiceDesc is the grpc.ServiceDesc for FinancialService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,7 +715,66 @@ var FinancialService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransfer",
 			Handler:    _FinancialService_GetTransfer_Handler,
 		},
+		{
+			MethodName: "ReserveFunds",
+			Handler:    _FinancialService_ReserveFunds_Handler,
+		},
+		{
+			MethodName: "CapturePending",
+			Handler:    _FinancialService_CapturePending_Handler,
+		},
+		{
+			MethodName: "VoidPending",
+			Handler:    _FinancialService_VoidPending_Handler,
+		},
+		{
+			MethodName: "CreateLinkedTransfers",
+			Handler:    _FinancialService_CreateLinkedTransfers_Handler,
+		},
+		{
+			MethodName: "CreateAccountsBatch",
+			Handler:    _FinancialService_CreateAccountsBatch_Handler,
+		},
+		{
+			MethodName: "CreateTransfersBatch",
+			Handler:    _FinancialService_CreateTransfersBatch_Handler,
+		},
+		{
+			MethodName: "ListAccountTransfers",
+			Handler:    _FinancialService_ListAccountTransfers_Handler,
+		},
+		{
+			MethodName: "GetAccountBalances",
+			Handler:    _FinancialService_GetAccountBalances_Handler,
+		},
+		{
+			MethodName: "GetBalanceAt",
+			Handler:    _FinancialService_GetBalanceAt_Handler,
+		},
+		{
+			MethodName: "QueryAccounts",
+			Handler:    _FinancialService_QueryAccounts_Handler,
+		},
+		{
+			MethodName: "QueryTransfers",
+			Handler:    _FinancialService_QueryTransfers_Handler,
+		},
+		{
+			MethodName: "GetImportCheckpoint",
+			Handler:    _FinancialService_GetImportCheckpoint_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportAccounts",
+			Handler:       _FinancialService_ImportAccounts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportTransfers",
+			Handler:       _FinancialService_ImportTransfers_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/financial.proto",
 }